@@ -44,6 +44,11 @@ func main() {
 		logger.Error("failed to load configuration", slog.String("err", err.Error()))
 		os.Exit(1)
 	}
+	moneyFormat, err := config.DefaultMoneyFormat("/app/config/.env.dev")
+	if err != nil {
+		logger.Error("failed to load money format", slog.String("err", err.Error()))
+		os.Exit(1)
+	}
 	logger.Info("Configuration loaded")
 
 	db, err := repositories.NewPostgresDB(ctx, cfg.PGDSN)
@@ -75,11 +80,21 @@ func main() {
 	DBrepository := repositories.NewSQLRepository(db, logger)
 	redisDBRepository := repositories.NewRedisRepository(redisDBData.DB, redisDBData.TTL, logger)
 
+	regionsProcessor := processors.NewRegions(DBrepository, redisDBRepository, logger)
 	regionIncomesProcessor := processors.NewAverageIncome(DBrepository, redisDBRepository, logger)
-	GetRegionIncomesAPIService := openapi.NewGetRegionIncomesAPIService(regionIncomesProcessor, logger)
-	GetRegionIncomesAPIController := openapi.NewGetRegionIncomesAPIController(GetRegionIncomesAPIService)
+	errorHandler := openapi.NewErrorHandler(logger)
+	GetRegionIncomesAPIService := openapi.NewGetRegionIncomesAPIService(regionIncomesProcessor, regionsProcessor, *moneyFormat, logger)
+	GetRegionIncomesAPIController := openapi.NewGetRegionIncomesAPIController(GetRegionIncomesAPIService, openapi.WithGetRegionIncomesAPIErrorHandler(errorHandler))
+
+	RegionsAPIService := openapi.NewRegionsAPIService(regionsProcessor, logger)
+	RegionsAPIController := openapi.NewRegionsAPIController(RegionsAPIService, openapi.WithRegionsAPIErrorHandler(errorHandler))
+
+	ingestionRejectsProcessor := processors.NewIngestionRejects(DBrepository, repositories.NewExcelReader(logger, 1, 0, cfg.SheetLayout), logger)
+	ingestionRunsProcessor := processors.NewIngestionRuns(DBrepository, logger)
+	IngestionAPIService := openapi.NewIngestionAPIService(ingestionRejectsProcessor, ingestionRunsProcessor, logger)
+	IngestionAPIController := openapi.NewIngestionAPIController(IngestionAPIService, openapi.WithIngestionAPIErrorHandler(errorHandler))
 
-	router := openapi.NewRouter(GetRegionIncomesAPIController)
+	router := openapi.NewRouter(GetRegionIncomesAPIController, RegionsAPIController, IngestionAPIController)
 
 	requestLogger := middleware.RequestLogger(logger)
 	router.Use(middleware.RequestIDMiddleware, requestLogger)
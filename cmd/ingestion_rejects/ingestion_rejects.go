@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/config"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/processors"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/repositories"
+)
+
+const usage = `Usage:
+  ingestion_rejects [-env path] list [-status open|resolved|all] [-limit n]
+  ingestion_rejects [-env path] reprocess [id ...]
+
+Rejects are source rows that could not be loaded: rows that failed to parse and rows whose
+region did not resolve. After fixing aliases or parsing rules, reprocess parses the open rejects
+again (all of them, or only the given ids) and loads the rows that now succeed. Given ids that
+are not open rejects are listed, and reprocess fails when none of them is open.
+`
+
+func main() {
+	envPath := flag.String("env", "/app/config/.env.dev", "path to the .env file")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	if err := run(context.Background(), *envPath, flag.Args(), logger); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, envPath string, args []string, logger *slog.Logger) error {
+	if len(args) == 0 {
+		flag.Usage()
+		return fmt.Errorf("no command given")
+	}
+
+	cfg, err := config.DefaultParserConfig(envPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := repositories.NewPostgresDB(ctx, cfg.PGDSN)
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %w", err)
+	}
+	defer db.Close()
+
+	rejectsProcessor := processors.NewIngestionRejects(
+		repositories.NewSQLRepository(db, logger),
+		repositories.NewExcelReader(logger, cfg.MaxRetries, cfg.RetryDelay, cfg.SheetLayout),
+		logger,
+	)
+
+	switch args[0] {
+	case "list":
+		listFlags := flag.NewFlagSet("list", flag.ContinueOnError)
+		statusFlag := listFlags.String("status", string(domain.IngestionRejectOpen), "open, resolved or all")
+		limit := listFlags.Int("limit", 100, "maximum number of rejects, 0 for all")
+		if err := listFlags.Parse(args[1:]); err != nil {
+			return err
+		}
+		status, err := domain.ParseIngestionRejectStatus(*statusFlag)
+		if err != nil {
+			return err
+		}
+
+		rejects, err := rejectsProcessor.GetIngestionRejects(ctx, status, int32(*limit))
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tFILE\tSHEET\tROW\tREASON\tRESOLVED_AT\tCELLS")
+		for _, reject := range rejects {
+			resolvedAt := "-"
+			if reject.ResolvedAt != nil {
+				resolvedAt = reject.ResolvedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%s\t%s\n",
+				reject.ID, reject.Row.File, reject.Row.Sheet, reject.Row.RowNumber, reject.Reason, resolvedAt,
+				strings.Join(reject.Row.Cells, " | "))
+		}
+		return w.Flush()
+	case "reprocess":
+		ids := make([]int64, 0, len(args)-1)
+		for _, arg := range args[1:] {
+			id, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid reject id [%s]: %w", arg, err)
+			}
+			ids = append(ids, id)
+		}
+
+		result, err := rejectsProcessor.ReprocessRejects(ctx, ids)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reprocessed: %d, resolved: %d, still rejected: %d\n", result.Reprocessed, result.Resolved, result.Rejected)
+		if len(result.NotOpen) > 0 {
+			fmt.Printf("not open (unknown or already resolved): %s\n", joinIds(result.NotOpen))
+		}
+		if len(ids) > 0 && result.Reprocessed == 0 {
+			return fmt.Errorf("none of the rejects [%s] is open", joinIds(ids))
+		}
+		return nil
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command [%s]", args[0])
+	}
+}
+
+func joinIds(ids []int64) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -14,41 +14,153 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
+	"text/tabwriter"
 	"time"
 
 	"github.com/donskova1ex/AverageRegionIncomes/internal/config"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
 
-	"github.com/donskova1ex/AverageRegionIncomes/internal"
 	"github.com/donskova1ex/AverageRegionIncomes/internal/processors"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/donskova1ex/AverageRegionIncomes/internal/repositories"
 )
 
+const usage = `Usage:
+  excel_reader [-env path] daemon [-source name]
+  excel_reader [-env path] download [-source name]
+  excel_reader [-env path] ingest -file path [-source name] [-force]
+  excel_reader [-env path] validate -file path [-source name]
+  excel_reader [-env path] backfill -dir path [-source name] [-pattern glob] [-force]
+
+daemon downloads and ingests the sources at startup and then every PARSING_INTERVAL until it
+is stopped; download does it once. ingest loads a local file, such as an earlier dated
+download, and backfill loads the files of a directory in name order, by default the dated
+downloads of the source, such as "sdmx_*.xml". Both record an ingestion run per file under its
+file:// URL, skip a file already loaded unless -force is given, and date its values by the date
+in its name, so that an older download does not replace the figures of a newer one.
+validate only parses a file and prints its statistics and rejected rows: it does not use the
+database, so region names are not resolved.
+
+Sources are "workbook", the Rosstat file as XLSX, ODS or CSV, and "sdmx", the SDMX-ML feed
+when SDMX_URL is set. daemon and download read every configured source unless -source is
+given; the other commands read the workbook unless -source is given.
+
+Exit codes: 0 success, 1 failure, 2 usage error, 3 done but some rows were rejected.
+`
+
+const (
+	exitOK       = 0
+	exitFailure  = 1
+	exitUsage    = 2
+	exitRejected = 3
+)
+
+var (
+	errUsage = errors.New("usage error")
+	// errRowsRejected reports a command that completed but put some rows into quarantine.
+	errRowsRejected = errors.New("some rows were rejected")
+)
+
 func main() {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	envPath := flag.String("env", "/app/config/.env.dev", "path to the .env file")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
 
-	logJSONHandler := slog.NewJSONHandler(os.Stdout, nil)
+	// Logs go to stderr, so that the output of the one-off commands stays readable.
+	logJSONHandler := slog.NewJSONHandler(os.Stderr, nil)
 	logger := slog.New(logJSONHandler)
 	slog.SetDefault(logger)
-	logger.Info(
-		"Server started",
-	)
 
-	cfg, err := config.DefaultParserConfig("/app/config/.env.dev")
+	err := run(context.Background(), *envPath, flag.Args(), logger)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	os.Exit(exitCode(err))
+}
 
+func exitCode(err error) int {
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errUsage):
+		return exitUsage
+	case errors.Is(err, errRowsRejected):
+		return exitRejected
+	default:
+		return exitFailure
+	}
+}
+
+func run(ctx context.Context, envPath string, args []string, logger *slog.Logger) error {
+	if len(args) == 0 {
+		flag.Usage()
+		return fmt.Errorf("%w: no command given", errUsage)
+	}
+	command := args[0]
+
+	commandFlags := flag.NewFlagSet(command, flag.ContinueOnError)
+	sourceName := commandFlags.String("source", "", `source to read: "workbook" or "sdmx"`)
+	var filePath, dirPath, pattern *string
+	force := new(bool)
+	switch command {
+	case "daemon", "download":
+	case "ingest", "validate":
+		filePath = commandFlags.String("file", "", "path of the source file (required)")
+	case "backfill":
+		dirPath = commandFlags.String("dir", "", "directory of the source files (required)")
+		pattern = commandFlags.String("pattern", "", "glob pattern of the file names to load (default: the dated downloads of the source)")
+	default:
+		flag.Usage()
+		return fmt.Errorf("%w: unknown command [%s]", errUsage, command)
+	}
+	if command == "ingest" || command == "backfill" {
+		force = commandFlags.Bool("force", false, "load files whose contents are already loaded")
+	}
+	if err := commandFlags.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	if commandFlags.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", errUsage, commandFlags.Args())
+	}
+	if filePath != nil && *filePath == "" {
+		return fmt.Errorf("%w: %s needs -file", errUsage, command)
+	}
+	if dirPath != nil && *dirPath == "" {
+		return fmt.Errorf("%w: %s needs -dir", errUsage, command)
+	}
+	if pattern != nil {
+		if _, err := filepath.Match(*pattern, ""); err != nil {
+			return fmt.Errorf("%w: invalid -pattern [%s]: %w", errUsage, *pattern, err)
+		}
+	}
+
+	cfg, err := config.DefaultParserConfig(envPath)
 	if err != nil {
-		logger.Error("failed to load configuration", slog.String("err", err.Error()))
-		os.Exit(1)
+		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 	logger.Info("Configuration loaded")
 
+	srcs, err := selectSources(sources(cfg, logger), *sourceName, command == "daemon" || command == "download")
+	if err != nil {
+		return err
+	}
+
+	if command == "validate" {
+		return validateFile(cfg, srcs[0], *filePath)
+	}
+
 	db, err := repositories.NewPostgresDB(ctx, cfg.PGDSN)
 	if err != nil {
-		logger.Error("error connecting to database", slog.String("err", err.Error()))
-		return
+		return fmt.Errorf("error connecting to database: %w", err)
 	}
 	defer func(db *sqlx.DB) {
 		err := db.Close()
@@ -60,22 +172,47 @@ func main() {
 
 	repository := repositories.NewSQLRepository(db, logger)
 
-	gracefulCloser := internal.NewGracefulCloser()
-	gracefulCloser.Add(func() error {
-		logger.Info("closing db connection")
-		if err := db.Close(); err != nil {
-			logger.Error("error closing db connection", slog.String("err", err.Error()))
+	switch command {
+	case "daemon":
+		runDaemon(ctx, repository, logger, cfg, srcs)
+		return nil
+	case "download":
+		return processSources(ctx, repository, logger, cfg, srcs)
+	case "ingest":
+		return ingestFiles(ctx, repository, logger, cfg, srcs[0], []string{*filePath}, *force)
+	default:
+		if *pattern == "" {
+			*pattern = datedFilePattern(srcs[0].fileName)
+		}
+		paths, err := backfillFiles(*dirPath, *pattern)
+		if err != nil {
 			return err
 		}
-		logger.Info("db connection closed")
-		return nil
-	})
+		return ingestFiles(ctx, repository, logger, cfg, srcs[0], paths, *force)
+	}
+}
+
+// runDaemon ingests the sources at startup and then every ParsingInterval, until an interrupt
+// or SIGTERM stops it. The database connection belongs to run, which closes it on return.
+func runDaemon(
+	ctx context.Context,
+	repository *repositories.SQLRepository,
+	logger *slog.Logger,
+	cfg *config.ParserConfig,
+	srcs []*source,
+) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logger.Info(
+		"Server started",
+	)
 
 	signalCtx, signalCancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
 	defer signalCancel()
 
 	logger.Info("First initialization started")
-	firstInitialization(logger, cfg, ctx, repository)
+	firstInitialization(logger, cfg, ctx, repository, srcs)
 	logger.Info("First initialization finished successfully")
 
 	ticker := time.NewTicker(cfg.ParsingInterval)
@@ -87,59 +224,344 @@ func main() {
 		<-signalCtx.Done()
 		logger.Info("interrupt signal received")
 		cancel()
-
-		gracefulCloser.Run(signalCtx, logger)
-		logger.Info("graceful shutdown complete")
 	}()
 
 	for {
 		select {
 		case <-ticker.C:
-			logger.Info("Download file started")
-			downloadFile(cfg, logger)
-			logger.Info("Download file finished")
 			logger.Info("Parser started")
-			processExcelFile(ctx, repository, logger, cfg)
+			// Failures are logged by processSources; the daemon keeps running.
+			_ = processSources(ctx, repository, logger, cfg, srcs)
 			logger.Info("Parser finished")
 		case <-ctx.Done():
 			logger.Info("shutting down parser")
 			return
 		}
 	}
+}
+
+// source is a place the indicator is downloaded from, together with the reader of its files.
+type source struct {
+	name     string
+	url      string
+	fileName string
+	// session tells whether the download needs the cookie session of the Rosstat site.
+	session bool
+	reader  processors.ExcelFileReader
+}
+
+// sources lists the configured sources: the Rosstat workbook, read as XLSX, ODS or CSV, and
+// the SDMX feed when one is configured.
+func sources(cfg *config.ParserConfig, logger *slog.Logger) []*source {
+	sources := []*source{{
+		name:     "workbook",
+		url:      sourceFileURL(cfg),
+		fileName: cfg.DefaultFileName,
+		session:  true,
+		reader:   repositories.NewExcelReader(logger, cfg.MaxRetries, cfg.RetryDelay, cfg.SheetLayout),
+	}}
+	if cfg.SDMXSource != nil {
+		sources = append(sources, &source{
+			name:     "sdmx",
+			url:      cfg.SDMXSource.URL,
+			fileName: cfg.SDMXSource.FileName,
+			reader:   repositories.NewSDMXReader(logger, cfg.SDMXSource),
+		})
+	}
+	return sources
+}
 
+// selectSources returns the source with the given name or, without a name, every source when
+// all is set and the workbook otherwise.
+func selectSources(srcs []*source, name string, all bool) ([]*source, error) {
+	if name == "" {
+		if all {
+			return srcs, nil
+		}
+		return srcs[:1], nil
+	}
+	names := make([]string, 0, len(srcs))
+	for _, src := range srcs {
+		if src.name == name {
+			return []*source{src}, nil
+		}
+		names = append(names, src.name)
+	}
+	return nil, fmt.Errorf("%w: unknown or unconfigured source [%s], expected one of: %s", errUsage, name, strings.Join(names, ", "))
 }
 
-func processExcelFile(
+// processSources ingests the sources in turn; a failing source does not stop the others. The
+// error joins the failures, or reports rejected rows when every source was ingested.
+func processSources(
 	ctx context.Context,
 	repository *repositories.SQLRepository,
 	logger *slog.Logger,
 	readerCfg *config.ParserConfig,
-) {
+	srcs []*source,
+) error {
+	var errs []error
+	rejected := false
+	for _, src := range srcs {
+		run, err := processSource(ctx, repository, logger.With("source", src.name), readerCfg, src)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("source [%s]: %w", src.name, err))
+			continue
+		}
+		rejected = rejected || run.RowsRejected > 0
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if rejected {
+		return errRowsRejected
+	}
+	return nil
+}
 
-	reader := repositories.NewExcelReader(logger, readerCfg.MaxRetries, readerCfg.RetryDelay)
+// processSource downloads the source file and ingests it. The download is conditional on the
+// validators of the last successful run of the source, so an unchanged source is neither
+// downloaded nor parsed; the skipped run is still recorded.
+func processSource(
+	ctx context.Context,
+	repository *repositories.SQLRepository,
+	logger *slog.Logger,
+	readerCfg *config.ParserConfig,
+	src *source,
+) (*domain.IngestionRun, error) {
+	eReaderProcessor := processors.NewExcelReader(repository, src.reader, readerCfg.AnnualTolerancePercent, logger)
 
-	fPath := filePathConstructor(readerCfg.ContainerDir, readerCfg.DefaultFileName)
+	lastRun, err := eReaderProcessor.LastSuccessfulRun(ctx, src.url)
+	if err != nil {
+		logger.Error("failed to get last successful run", slog.String("err", err.Error()))
+		return nil, err
+	}
 
-	incomes, err := reader.ReadFile(fPath)
+	logger.Info("Download file started")
+	client, err := newHTTPClient(readerCfg, logger, src.session)
+	if err != nil {
+		logger.Error("failed to prepare download", slog.String("err", err.Error()))
+		return nil, err
+	}
+	ingestionSource, err := downloadFile(client, src.url, filePathConstructor(readerCfg.ContainerDir, src.fileName), logger, lastRun)
+	if err != nil {
+		logger.Error("failed to download file", slog.String("err", err.Error()))
+		return nil, err
+	}
+	logger.Info("Download file finished", "not modified", ingestionSource.NotModified)
+
+	return ingestSource(ctx, eReaderProcessor, logger, ingestionSource)
+}
+
+// ingestFiles loads local files of the source in the given order, each in a run of its own,
+// and prints the outcome of every run. A failing file does not stop the others. The runs are
+// recorded under the file's own URL rather than the source's, so that the next download is
+// still compared with the previous download, and the values are dated by the download date in
+// the file name, so that an older download does not replace newer figures.
+func ingestFiles(
+	ctx context.Context,
+	repository *repositories.SQLRepository,
+	logger *slog.Logger,
+	readerCfg *config.ParserConfig,
+	src *source,
+	paths []string,
+	force bool,
+) error {
+	eReaderProcessor := processors.NewExcelReader(repository, src.reader, readerCfg.AnnualTolerancePercent, logger)
+
+	failed, rejected := 0, 0
+	for _, path := range paths {
+		ingestionSource, err := localIngestionSource(path)
+		if err == nil {
+			ingestionSource.LastRun, err = eReaderProcessor.LastSuccessfulRun(ctx, ingestionSource.URL)
+		}
+		var run *domain.IngestionRun
+		if err == nil {
+			ingestionSource.Force = force
+			run, err = ingestSource(ctx, eReaderProcessor, logger, ingestionSource)
+		}
+		if err != nil {
+			fmt.Printf("%s: failed: %s\n", path, err)
+			failed++
+			continue
+		}
+		printRun(run)
+		if run.RowsRejected > 0 {
+			rejected++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed to ingest", failed, len(paths))
+	}
+	if rejected > 0 {
+		return fmt.Errorf("%w in %d of %d files", errRowsRejected, rejected, len(paths))
+	}
+	return nil
+}
+
+// localIngestionSource describes a local file, such as an earlier dated download. The download
+// date is read from the name filePathConstructor gives the file, or is its modification time.
+func localIngestionSource(path string) (*domain.IngestionSource, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve file path: %w", err)
+	}
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	downloadedAt := info.ModTime()
+	name := strings.TrimSuffix(info.Name(), filepath.Ext(info.Name()))
+	if idx := strings.LastIndex(name, "_"); idx >= 0 {
+		if date, err := time.ParseInLocation(time.DateOnly, name[idx+1:], time.Local); err == nil {
+			downloadedAt = date
+		}
+	}
+	return &domain.IngestionSource{
+		URL:          (&url.URL{Scheme: "file", Path: absPath}).String(),
+		LocalPath:    path,
+		DownloadedAt: downloadedAt,
+	}, nil
+}
+
+// backfillFiles lists the files of the directory whose names match the pattern, in name order,
+// which is the date order of the dated downloads.
+func backfillFiles(dirPath, pattern string) ([]string, error) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if matched, _ := filepath.Match(pattern, entry.Name()); matched {
+			paths = append(paths, filepath.Join(dirPath, entry.Name()))
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no files matching [%s] in [%s]", pattern, dirPath)
+	}
+	return paths, nil
+}
+
+// sourceIngester records and loads one ingestion run of a source file.
+type sourceIngester interface {
+	IngestSource(ctx context.Context, source *domain.IngestionSource) (*domain.IngestionRun, error)
+}
+
+func ingestSource(
+	ctx context.Context,
+	eReaderProcessor sourceIngester,
+	logger *slog.Logger,
+	ingestionSource *domain.IngestionSource,
+) (*domain.IngestionRun, error) {
+	run, err := eReaderProcessor.IngestSource(ctx, ingestionSource)
 	if err != nil {
 		logger.Error(
-			"failed to read file",
+			"failed to ingest file",
 			slog.String("err", err.Error()),
-			slog.String("filepath", fPath),
+			slog.String("filepath", ingestionSource.LocalPath),
 		)
-		os.Exit(1)
+		return nil, err
+	}
+
+	if run.Status == domain.IngestionRunSkipped {
+		logger.Info("Source unchanged, ingestion skipped",
+			"run id", run.ID,
+			"reason", run.SkipReason)
+		return run, nil
+	}
+
+	logger.Info("Successfully saved records to database",
+		"run id", run.ID,
+		"filepath", run.LocalPath,
+		"sha256", run.FileSHA256,
+		"rows parsed", run.RowsParsed,
+		"rows inserted", run.RowsInserted,
+		"annual rows inserted", run.AnnualRowsInserted,
+		"rows rejected", run.RowsRejected,
+		"unresolved regions", len(run.UnmatchedRegions))
+	return run, nil
+}
+
+func printRun(run *domain.IngestionRun) {
+	if run.Status == domain.IngestionRunSkipped {
+		fmt.Printf("%s: run %d skipped: %s\n", run.LocalPath, run.ID, run.SkipReason)
+		return
+	}
+	fmt.Printf("%s: run %d %s, rows parsed: %d, inserted: %d, annual inserted: %d, rejected: %d, unresolved regions: %s\n",
+		run.LocalPath, run.ID, run.Status, run.RowsParsed, run.RowsInserted, run.AnnualRowsInserted, run.RowsRejected,
+		strings.Join(run.UnmatchedRegions, ", "))
+}
+
+// validateFile parses a file of the source and prints what an ingestion would load, the annual
+// totals that disagree with their quarters and the rows that would be rejected.
+func validateFile(cfg *config.ParserConfig, src *source, filePath string) error {
+	parsed, err := src.reader.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("error reading file: %w", err)
 	}
 
-	logger.Info("Successfully read file",
-		"filepath", fPath,
-		"records", len(incomes))
+	missing := 0
+	for _, income := range parsed.Incomes {
+		if income.Missing {
+			missing++
+		}
+	}
+	mismatches := domain.CheckAnnualTotals(parsed.Incomes, cfg.AnnualTolerancePercent)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "file:\t%s\n", parsed.Path)
+	fmt.Fprintf(w, "source:\t%s\n", src.name)
+	fmt.Fprintf(w, "sheets:\t%d\n", parsed.SheetCount)
+	fmt.Fprintf(w, "rows:\t%d\n", parsed.RowCount)
+	fmt.Fprintf(w, "values:\t%d\n", len(parsed.Incomes))
+	fmt.Fprintf(w, "missing values:\t%d\n", missing)
+	fmt.Fprintf(w, "annual mismatches:\t%d\n", len(mismatches))
+	fmt.Fprintf(w, "rejected rows:\t%d\n", len(parsed.Rejects))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(mismatches) > 0 {
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SHEET\tREGION\tYEAR\tANNUAL\tQUARTER_MEAN\tDEVIATION_%")
+		for _, mismatch := range mismatches {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n",
+				mismatch.Sheet, mismatch.Region, mismatch.Year, mismatch.Annual, mismatch.QuarterMean,
+				mismatch.DeviationPercent.StringFixed(2))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
 
-	eReaderProcessor := processors.NewExcelReader(repository, logger)
-	if err := eReaderProcessor.ExcelReaderRepository.CreateRegionIncomes(ctx, incomes); err != nil {
-		logger.Error("failed to create region incomes", slog.String("err", err.Error()))
+	if len(parsed.Rejects) > 0 {
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SHEET\tROW\tREASON\tCELLS")
+		for _, reject := range parsed.Rejects {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n",
+				reject.Row.Sheet, reject.Row.RowNumber, reject.Reason, strings.Join(reject.Row.Cells, " | "))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %d of %d rows", errRowsRejected, len(parsed.Rejects), parsed.RowCount)
 	}
+	return nil
+}
 
-	logger.Info("Successfully saved records to database", "strings read", len(incomes))
+func sourceFileURL(cfg *config.ParserConfig) string {
+	return fmt.Sprintf("%s%s", cfg.FileStorageURL, cfg.DefaultFileName)
+}
+
+// datedFilePattern matches the names filePathConstructor gives the downloads of a file.
+func datedFilePattern(fileName string) string {
+	fileExtension := filepath.Ext(fileName)
+	return fileName[:len(fileName)-len(fileExtension)] + "_*" + fileExtension
 }
 
 func filePathConstructor(filePath, fileName string) string {
@@ -153,15 +575,12 @@ func filePathConstructor(filePath, fileName string) string {
 	return datedFileName
 }
 
-func downloadFile(cfg *config.ParserConfig, logger *slog.Logger) {
-	flag.Parse()
-
+// newHTTPClient returns the client sources are downloaded with. With session set, it first
+// visits the SSL cookie URL to get the session cookies the Rosstat site requires.
+func newHTTPClient(cfg *config.ParserConfig, logger *slog.Logger, session bool) (*http.Client, error) {
 	jar, err := cookiejar.New(nil)
 	if err != nil {
-		logger.Error(
-			"failed to create cookie jar",
-			slog.String("err", err.Error()),
-		)
+		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
 	}
 	logger.Info("Successfully created cookie jar")
 
@@ -173,31 +592,19 @@ func downloadFile(cfg *config.ParserConfig, logger *slog.Logger) {
 		Jar:       jar,
 		Transport: transport,
 	}
+	if !session {
+		return client, nil
+	}
 
 	sslURL, err := url.Parse(cfg.SslCookieURL)
 	if err != nil {
-		logger.Error(
-			"failed to parse ssl cookie url",
-			slog.String("err", err.Error()),
-		)
-
+		return nil, fmt.Errorf("failed to parse ssl cookie url: %w", err)
 	}
 	logger.Info("Successfully parsed ssl cookie url")
 
-	if sslURL == nil {
-		nilSSLError := errors.New("nil ssl cookie url")
-		logger.Error(
-			"ssl cookie url is empty",
-			slog.String("err", nilSSLError.Error()),
-		)
-	}
-
 	resp, err := client.Get(sslURL.String())
 	if err != nil {
-		logger.Error(
-			"error establishing session",
-			slog.String("err", err.Error()),
-		)
+		return nil, fmt.Errorf("error establishing session: %w", err)
 	}
 	logger.Info("Successfully established session")
 
@@ -211,11 +618,31 @@ func downloadFile(cfg *config.ParserConfig, logger *slog.Logger) {
 
 	cookies := jar.Cookies(sslURL)
 	logger.Info(fmt.Sprintf("Recived [%d] cookies from [%s]", len(cookies), sslURL.String()))
+	return client, nil
+}
+
+// downloadFile downloads sourceURL into localPath. When lastRun kept the ETag or Last-Modified
+// of its download, the request is conditional and a 304 Not Modified answer returns the source
+// of lastRun without downloading anything.
+func downloadFile(client *http.Client, sourceURL, localPath string, logger *slog.Logger, lastRun *domain.IngestionRun) (*domain.IngestionSource, error) {
+	source := &domain.IngestionSource{URL: sourceURL, LastRun: lastRun}
 
-	fileURL := fmt.Sprintf("%s%s", cfg.FileStorageURL, cfg.DefaultFileName)
-	resp, err = client.Get(fileURL)
+	req, err := http.NewRequest(http.MethodGet, source.URL, nil)
 	if err != nil {
-		logger.Error("failed to download file", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+	if lastRun != nil {
+		if lastRun.ETag != "" {
+			req.Header.Set("If-None-Match", lastRun.ETag)
+		}
+		if lastRun.LastModified != "" {
+			req.Header.Set("If-Modified-Since", lastRun.LastModified)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
@@ -227,19 +654,31 @@ func downloadFile(cfg *config.ParserConfig, logger *slog.Logger) {
 		}
 	}(resp.Body)
 
-	fileExt := filepath.Ext(cfg.DefaultFileName)
-	fileName := (cfg.DefaultFileName)[:len(cfg.DefaultFileName)-len(fileExt)]
-	datedFileName := fmt.Sprintf(
-		"%s%s_%s%s",
-		cfg.ContainerDir,
-		fileName,
-		time.Now().Format("2006-01-02"),
-		fileExt,
-	)
+	source.ETag = resp.Header.Get("ETag")
+	source.LastModified = resp.Header.Get("Last-Modified")
 
-	file, err := os.Create(datedFileName)
+	if resp.StatusCode == http.StatusNotModified && lastRun != nil {
+		logger.Info("Source file not modified since the last download")
+		source.NotModified = true
+		source.LocalPath = lastRun.LocalPath
+		if source.ETag == "" {
+			source.ETag = lastRun.ETag
+		}
+		if source.LastModified == "" {
+			source.LastModified = lastRun.LastModified
+		}
+		return source, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status [%s] downloading file", resp.Status)
+	}
+
+	source.LocalPath = localPath
+	source.DownloadedAt = time.Now()
+
+	file, err := os.Create(source.LocalPath)
 	if err != nil {
-		logger.Error("failed to create file", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("failed to create file: %w", err)
 	}
 	defer func(file *os.File) {
 		err := file.Close()
@@ -254,19 +693,14 @@ func downloadFile(cfg *config.ParserConfig, logger *slog.Logger) {
 
 	_, err = io.Copy(file, resp.Body)
 	if err != nil {
-		logger.Error(
-			"failed writing file",
-			slog.String("err", err.Error()),
-		)
+		return nil, fmt.Errorf("failed writing file: %w", err)
 	}
 	logger.Info("Successfully wrote file")
+	return source, nil
 }
 
-func firstInitialization(logger *slog.Logger, cfg *config.ParserConfig, ctx context.Context, repository *repositories.SQLRepository) {
-	logger.Info("Starting download file")
-	downloadFile(cfg, logger)
-	logger.Info("Downloading complete")
+func firstInitialization(logger *slog.Logger, cfg *config.ParserConfig, ctx context.Context, repository *repositories.SQLRepository, srcs []*source) {
 	logger.Info("File parsing started")
-	processExcelFile(ctx, repository, logger, cfg)
+	_ = processSources(ctx, repository, logger, cfg, srcs)
 	logger.Info("File parsing finished")
 }
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testEnv writes an empty .env file and sets the variables the reader configuration requires,
+// so that run gets past loading it.
+func testEnv(t *testing.T) string {
+	t.Helper()
+	variables := map[string]string{
+		"POSTGRES_DSN":         "postgres://reader@localhost/incomes?sslmode=disable",
+		"READER_NAME":          "reader",
+		"READER_MAIN_DIR":      "/app",
+		"READER_CONTAINER_DIR": "/app/files/",
+		"DEFAULT_FILE_NAME":    "Doc_4-dohod.xlsx",
+		"SSL_COOKIE_URL":       "https://rosstat.gov.ru",
+		"PARSING_INTERVAL":     "5h",
+		"MAX_RETRIES":          "3",
+		"SDMX_URL":             "",
+	}
+	for name, value := range variables {
+		t.Setenv(name, value)
+	}
+	envPath := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envPath, nil, 0o644))
+	return envPath
+}
+
+func TestRunRejectsBadArguments(t *testing.T) {
+	envPath := testEnv(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name  string
+		args  []string
+		error string
+	}{
+		{name: "no command", args: nil, error: "no command given"},
+		{name: "unknown command", args: []string{"import"}, error: "unknown command [import]"},
+		{name: "unknown flag", args: []string{"download", "-file", "Doc.xlsx"}, error: "flag provided but not defined: -file"},
+		{name: "extra arguments", args: []string{"download", "Doc.xlsx"}, error: "unexpected arguments [Doc.xlsx]"},
+		{name: "ingest without file", args: []string{"ingest"}, error: "ingest needs -file"},
+		{name: "validate without file", args: []string{"validate", "-source", "sdmx"}, error: "validate needs -file"},
+		{name: "backfill without dir", args: []string{"backfill", "-pattern", "*.xlsx"}, error: "backfill needs -dir"},
+		{name: "bad pattern", args: []string{"backfill", "-dir", t.TempDir(), "-pattern", "Doc_[.xlsx"}, error: "invalid -pattern [Doc_[.xlsx]"},
+		{name: "unknown source", args: []string{"validate", "-file", "Doc.xlsx", "-source", "csv"}, error: "unknown or unconfigured source [csv], expected one of: workbook"},
+		{name: "unconfigured source", args: []string{"download", "-source", "sdmx"}, error: "unknown or unconfigured source [sdmx]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := run(context.Background(), envPath, tt.args, logger)
+			require.ErrorIs(t, err, errUsage)
+			require.ErrorContains(t, err, tt.error)
+			require.Equal(t, exitUsage, exitCode(err))
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "success", err: nil, code: exitOK},
+		{name: "help", err: flag.ErrHelp, code: exitOK},
+		{name: "usage", err: fmt.Errorf("%w: no command given", errUsage), code: exitUsage},
+		{name: "rows rejected", err: fmt.Errorf("%w in 1 of 2 files", errRowsRejected), code: exitRejected},
+		{name: "failure", err: errors.New("error connecting to database"), code: exitFailure},
+		{
+			name: "joined failures",
+			err:  errors.Join(errors.New("source [workbook]: timeout"), errors.New("source [sdmx]: not found")),
+			code: exitFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.code, exitCode(tt.err))
+		})
+	}
+}
+
+func TestBackfillFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"Doc_4-dohod_2025-06-02.xlsx",
+		"Doc_4-dohod_2024-12-01.xlsx",
+		"Doc_4-dohod_2025-01-15.xlsx",
+		"sdmx_2025-01-15.xml",
+		"notes.txt",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "Doc_4-dohod_archive.xlsx"), 0o755))
+
+	tests := []struct {
+		name    string
+		pattern string
+		files   []string
+		error   string
+	}{
+		{
+			name:    "dated workbooks",
+			pattern: datedFilePattern("Doc_4-dohod.xlsx"),
+			files:   []string{"Doc_4-dohod_2024-12-01.xlsx", "Doc_4-dohod_2025-01-15.xlsx", "Doc_4-dohod_2025-06-02.xlsx"},
+		},
+		{
+			name:    "dated sdmx messages",
+			pattern: datedFilePattern("sdmx.xml"),
+			files:   []string{"sdmx_2025-01-15.xml"},
+		},
+		{
+			name:    "one year",
+			pattern: "Doc_4-dohod_2025-*.xlsx",
+			files:   []string{"Doc_4-dohod_2025-01-15.xlsx", "Doc_4-dohod_2025-06-02.xlsx"},
+		},
+		{name: "no match", pattern: "*.ods", error: "no files matching [*.ods]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := backfillFiles(dir, tt.pattern)
+			if tt.error != "" {
+				require.ErrorContains(t, err, tt.error)
+				return
+			}
+			require.NoError(t, err)
+			expected := make([]string, 0, len(tt.files))
+			for _, file := range tt.files {
+				expected = append(expected, filepath.Join(dir, file))
+			}
+			require.Equal(t, expected, paths)
+		})
+	}
+
+	_, err := backfillFiles(filepath.Join(dir, "missing"), "*")
+	require.ErrorContains(t, err, "failed to read directory")
+}
+
+func TestLocalIngestionSource(t *testing.T) {
+	dir := t.TempDir()
+	dated := filepath.Join(dir, "Doc_4-dohod_2024-12-01.xlsx")
+	undated := filepath.Join(dir, "Doc_4-dohod.xlsx")
+	for _, path := range []string{dated, undated} {
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+	}
+	modTime := time.Date(2025, 3, 4, 5, 6, 7, 0, time.Local)
+	require.NoError(t, os.Chtimes(undated, modTime, modTime))
+
+	source, err := localIngestionSource(dated)
+	require.NoError(t, err)
+	require.Equal(t, "file://"+dated, source.URL)
+	require.Equal(t, dated, source.LocalPath)
+	require.Equal(t, time.Date(2024, 12, 1, 0, 0, 0, 0, time.Local), source.DownloadedAt)
+
+	source, err = localIngestionSource(undated)
+	require.NoError(t, err)
+	require.True(t, modTime.Equal(source.DownloadedAt))
+
+	_, err = localIngestionSource(filepath.Join(dir, "missing.xlsx"))
+	require.ErrorContains(t, err, "failed to read file")
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/config"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/processors"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/repositories"
+)
+
+const usage = `Usage:
+  region_aliases [-env path] list
+  region_aliases [-env path] add <region_id> <alias>
+
+Aliases are extra spellings of region names used to match rows of the source files to regions.
+Matching ignores case, spaces, dashes, ё/е and footnote markers such as "1)".
+`
+
+func main() {
+	envPath := flag.String("env", "/app/config/.env.dev", "path to the .env file")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	if err := run(context.Background(), *envPath, flag.Args(), logger); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, envPath string, args []string, logger *slog.Logger) error {
+	if len(args) == 0 {
+		flag.Usage()
+		return fmt.Errorf("no command given")
+	}
+
+	cfg, err := config.DefaultParserConfig(envPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := repositories.NewPostgresDB(ctx, cfg.PGDSN)
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %w", err)
+	}
+	defer db.Close()
+
+	aliasesProcessor := processors.NewRegionAliases(repositories.NewSQLRepository(db, logger), logger)
+
+	switch args[0] {
+	case "list":
+		aliases, err := aliasesProcessor.GetRegionAliases(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "REGION_ID\tALIAS\tCREATED_AT")
+		for _, alias := range aliases {
+			fmt.Fprintf(w, "%d\t%s\t%s\n", alias.RegionId, alias.Alias, alias.CreatedAt.Format("2006-01-02 15:04:05"))
+		}
+		return w.Flush()
+	case "add":
+		if len(args) != 3 {
+			flag.Usage()
+			return fmt.Errorf("add expects <region_id> <alias>")
+		}
+		regionId, err := strconv.ParseInt(args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid region_id [%s]: %w", args[1], err)
+		}
+		alias, err := aliasesProcessor.CreateRegionAlias(ctx, int32(regionId), args[2])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("alias [%s] added for region_id [%d]\n", alias.Alias, alias.RegionId)
+		return nil
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command [%s]", args[0])
+	}
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/joho/godotenv"
+)
+
+// DefaultMoneyFormat loads how money values are presented by the API:
+// - MONEY_SCALE: decimal places in responses, 2 by default
+// - MONEY_ROUNDING: half_up (default), half_even, down, up, ceil or floor
+// - MONEY_JSON_FORMAT: number (default) or string
+func DefaultMoneyFormat(envPath string) (*domain.MoneyFormat, error) {
+	err := godotenv.Load(envPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading .env file: %w", err)
+	}
+
+	moneyFormat := domain.DefaultMoneyFormat()
+
+	if scaleStr := os.Getenv("MONEY_SCALE"); scaleStr != "" {
+		scale, err := strconv.ParseInt(scaleStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing MONEY_SCALE: %w", err)
+		}
+		if scale < 0 {
+			return nil, fmt.Errorf("MONEY_SCALE must not be negative, got %d", scale)
+		}
+		moneyFormat.Scale = int32(scale)
+	}
+
+	if roundingStr := os.Getenv("MONEY_ROUNDING"); roundingStr != "" {
+		rounding, err := domain.ParseRounding(roundingStr)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing MONEY_ROUNDING: %w", err)
+		}
+		moneyFormat.Rounding = rounding
+	}
+
+	switch jsonFormat := os.Getenv("MONEY_JSON_FORMAT"); jsonFormat {
+	case "", "number":
+		moneyFormat.AsString = false
+	case "string":
+		moneyFormat.AsString = true
+	default:
+		return nil, fmt.Errorf("MONEY_JSON_FORMAT must be number or string, got [%s]", jsonFormat)
+	}
+
+	return &moneyFormat, nil
+}
@@ -6,7 +6,9 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
 	"github.com/joho/godotenv"
+	"github.com/shopspring/decimal"
 )
 
 type ParserConfig struct {
@@ -20,6 +22,12 @@ type ParserConfig struct {
 	ContainerDir    string
 	SslCookieURL    string
 	FileStorageURL  string
+	SheetLayout     *domain.SheetLayout
+	// AnnualTolerancePercent is how far, in percent, the mean of the four quarters may deviate
+	// from the published annual total before a warning is logged.
+	AnnualTolerancePercent decimal.Decimal
+	// SDMXSource is the SDMX-ML feed read next to the workbook, nil when none is configured.
+	SDMXSource *domain.SDMXSource
 }
 
 func DefaultParserConfig(envPath string) (*ParserConfig, error) {
@@ -80,6 +88,27 @@ func DefaultParserConfig(envPath string) (*ParserConfig, error) {
 		return nil, fmt.Errorf("error parsing MAX_RETRIES: %w", err)
 	}
 
+	sheetLayout, err := DefaultSheetLayout(envPath)
+	if err != nil {
+		return nil, err
+	}
+
+	annualTolerance := decimal.NewFromInt(1)
+	if annualToleranceStr := os.Getenv("ANNUAL_TOLERANCE_PERCENT"); annualToleranceStr != "" {
+		annualTolerance, err = decimal.NewFromString(annualToleranceStr)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing ANNUAL_TOLERANCE_PERCENT: %w", err)
+		}
+		if annualTolerance.IsNegative() {
+			return nil, fmt.Errorf("ANNUAL_TOLERANCE_PERCENT must not be negative, got %s", annualTolerance)
+		}
+	}
+
+	sdmxSource, err := DefaultSDMXSource(envPath)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ParserConfig{
 		DefaultFileName: defaultFileName,
 		ParsingInterval: parsedInterval,
@@ -89,6 +118,10 @@ func DefaultParserConfig(envPath string) (*ParserConfig, error) {
 		ContainerDir:    readerContainerDir,
 		SslCookieURL:    sslCookieURL,
 		FileStorageURL:  fileUrlStorage,
+		SheetLayout:     sheetLayout,
+
+		AnnualTolerancePercent: annualTolerance,
+		SDMXSource:             sdmxSource,
 	}, nil
 }
 
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/joho/godotenv"
+)
+
+// DefaultSDMXSource loads the SDMX source, which is read next to the workbook when SDMX_URL is
+// set; without it the source is nil. The other variables override the matching field of
+// domain.DefaultSDMXSource:
+// - SDMX_URL: address of the SDMX-ML data message
+// - SDMX_FILE_NAME: name of the local copy
+// - SDMX_REGION_DIMENSION: dimension holding the region code, OKATO by default
+// - SDMX_TIME_DIMENSION: dimension holding the period, TIME_PERIOD by default
+// - SDMX_PERIOD_DIMENSION: dimension naming the quarter of a yearly period, such as "I квартал"
+// - SDMX_SERIES_FILTER: comma-separated DIMENSION=VALUE pairs the series must have
+func DefaultSDMXSource(envPath string) (*domain.SDMXSource, error) {
+	err := godotenv.Load(envPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading .env file: %w", err)
+	}
+
+	sourceURL := os.Getenv("SDMX_URL")
+	if sourceURL == "" {
+		return nil, nil
+	}
+
+	source := domain.DefaultSDMXSource()
+	source.URL = sourceURL
+
+	fields := []struct {
+		name  string
+		field *string
+	}{
+		{"SDMX_FILE_NAME", &source.FileName},
+		{"SDMX_REGION_DIMENSION", &source.RegionDimension},
+		{"SDMX_TIME_DIMENSION", &source.TimeDimension},
+		{"SDMX_PERIOD_DIMENSION", &source.PeriodDimension},
+	}
+	for _, field := range fields {
+		if value := os.Getenv(field.name); value != "" {
+			*field.field = value
+		}
+	}
+
+	source.SeriesFilter, err = domain.ParseSDMXSeriesFilter(os.Getenv("SDMX_SERIES_FILTER"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing SDMX_SERIES_FILTER: %w", err)
+	}
+
+	if err := source.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid sdmx source: %w", err)
+	}
+	return &source, nil
+}
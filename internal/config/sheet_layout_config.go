@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/joho/godotenv"
+)
+
+// DefaultSheetLayout loads the layout of the source sheets. Every variable is optional and
+// overrides the matching field of domain.DefaultSheetLayout:
+// - SHEET_HEADER_ROW, SHEET_SUBHEADER_ROW, SHEET_FIRST_DATA_ROW: 1-based rows
+// - SHEET_REGION_COLUMN: column letter of the region names
+// - SHEET_PERIOD_PATTERN: regexp of a period header, capturing the year
+// - SHEET_ANNUAL_PATTERN: regexp of the sub-header of an annual total column
+// - SHEET_QUARTER_PATTERN: regexp of the sub-header of a quarter column, capturing its number
+// - SHEET_FOOTER_ROWS: trailing rows that are not data
+// - SHEET_FOOTER_PATTERN: regexp of the region cell of the first footer row
+// - SHEET_INCLUDE, SHEET_EXCLUDE: comma-separated sheet names or glob patterns
+// - SHEET_NUMBER_LOCALE: ru or en, how values stored as text are written
+func DefaultSheetLayout(envPath string) (*domain.SheetLayout, error) {
+	err := godotenv.Load(envPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading .env file: %w", err)
+	}
+
+	layout := domain.DefaultSheetLayout()
+
+	rows := []struct {
+		name  string
+		field *int
+	}{
+		{"SHEET_HEADER_ROW", &layout.HeaderRow},
+		{"SHEET_SUBHEADER_ROW", &layout.SubHeaderRow},
+		{"SHEET_FIRST_DATA_ROW", &layout.FirstDataRow},
+		{"SHEET_FOOTER_ROWS", &layout.FooterRows},
+	}
+	for _, row := range rows {
+		if value := os.Getenv(row.name); value != "" {
+			*row.field, err = strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %s: %w", row.name, err)
+			}
+		}
+	}
+
+	if regionColumn := os.Getenv("SHEET_REGION_COLUMN"); regionColumn != "" {
+		layout.RegionColumn = regionColumn
+	}
+
+	patterns := []struct {
+		name  string
+		field **regexp.Regexp
+	}{
+		{"SHEET_PERIOD_PATTERN", &layout.PeriodPattern},
+		{"SHEET_ANNUAL_PATTERN", &layout.AnnualPattern},
+		{"SHEET_QUARTER_PATTERN", &layout.QuarterPattern},
+		{"SHEET_FOOTER_PATTERN", &layout.FooterPattern},
+	}
+	for _, pattern := range patterns {
+		if value := os.Getenv(pattern.name); value != "" {
+			*pattern.field, err = regexp.Compile(value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %s: %w", pattern.name, err)
+			}
+		}
+	}
+
+	layout.IncludeSheets = splitSheetList(os.Getenv("SHEET_INCLUDE"))
+	layout.ExcludeSheets = splitSheetList(os.Getenv("SHEET_EXCLUDE"))
+
+	if numberLocale := os.Getenv("SHEET_NUMBER_LOCALE"); numberLocale != "" {
+		layout.NumberLocale = domain.NumberLocale(numberLocale)
+	}
+
+	if err := layout.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid sheet layout: %w", err)
+	}
+	return &layout, nil
+}
+
+func splitSheetList(value string) []string {
+	var sheets []string
+	for _, sheet := range strings.Split(value, ",") {
+		if sheet = strings.TrimSpace(sheet); sheet != "" {
+			sheets = append(sheets, sheet)
+		}
+	}
+	return sheets
+}
@@ -1,9 +1,26 @@
 package domain
 
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AverageRegionIncomes is the average over the quarters between FirstYear.FirstQuarter and
+// LastYear.LastQuarter. Year and Quarter are the period the average describes: the requested
+// quarter when one is given, otherwise the newest quarter used; for a calendar year Quarter is 0.
+// Complete is false when fewer quarters than expected were found.
 type AverageRegionIncomes struct {
-	RegionId             int32   `db:"region_id" json:"RegionId"`
-	RegionName           string  `db:"region_name" json:"RegionName"`
-	Year                 int32   `db:"year" json:"Year"`
-	Quarter              int32   `db:"quarter" json:"Quarter"`
-	AverageRegionIncomes float32 `db:"average_region_incomes" json:"AverageRegionIncomes"`
+	RegionId             int32           `db:"region_id" json:"RegionId"`
+	RegionName           string          `db:"region_name" json:"RegionName"`
+	Year                 int32           `db:"year" json:"Year"`
+	Quarter              int32           `db:"quarter" json:"Quarter"`
+	AverageRegionIncomes decimal.Decimal `db:"average_region_incomes" json:"AverageRegionIncomes"`
+	FirstYear            int32           `db:"first_year" json:"FirstYear"`
+	FirstQuarter         int32           `db:"first_quarter" json:"FirstQuarter"`
+	LastYear             int32           `db:"last_year" json:"LastYear"`
+	LastQuarter          int32           `db:"last_quarter" json:"LastQuarter"`
+	QuartersCount        int32           `db:"quarters_count" json:"QuartersCount"`
+	Complete             bool            `db:"complete" json:"Complete"`
+	LoadedAt             time.Time       `db:"loaded_at" json:"LoadedAt"`
 }
@@ -0,0 +1,77 @@
+package domain
+
+import "fmt"
+
+type AveragingMethod string
+
+const (
+	AveragingMean     AveragingMethod = "mean"
+	AveragingMedian   AveragingMethod = "median"
+	AveragingWeighted AveragingMethod = "weighted"
+)
+
+type AveragingMode string
+
+const (
+	// AveragingTrailing averages the newest Window quarters up to the requested period.
+	AveragingTrailing AveragingMode = "trailing"
+	// AveragingCalendar averages Q1-Q4 of the requested year.
+	AveragingCalendar AveragingMode = "calendar"
+)
+
+const (
+	DefaultAveragingWindow int32 = 4
+	MaxAveragingWindow     int32 = 40
+)
+
+// Averaging describes how the average is computed: over a calendar year or over the newest
+// Window quarters, and with which method. Weighted means give the newest quarter the highest
+// weight, decreasing linearly to the oldest one.
+type Averaging struct {
+	Mode   AveragingMode
+	Window int32
+	Method AveragingMethod
+}
+
+func DefaultAveraging() Averaging {
+	return Averaging{Mode: AveragingTrailing, Window: DefaultAveragingWindow, Method: AveragingMean}
+}
+
+func ParseAveragingMode(s string) (AveragingMode, error) {
+	switch mode := AveragingMode(s); mode {
+	case AveragingTrailing, AveragingCalendar:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: unknown averaging mode [%s], expected one of: %s, %s", ErrInvalidParameter, s, AveragingTrailing, AveragingCalendar)
+	}
+}
+
+// ExpectedQuarters is the number of quarters a complete average is computed over.
+func (a Averaging) ExpectedQuarters() int32 {
+	if a.Mode == AveragingCalendar {
+		return 4
+	}
+	return a.Window
+}
+
+func ParseAveragingMethod(s string) (AveragingMethod, error) {
+	switch method := AveragingMethod(s); method {
+	case AveragingMean, AveragingMedian, AveragingWeighted:
+		return method, nil
+	default:
+		return "", fmt.Errorf("%w: unknown averaging method [%s], expected one of: %s, %s, %s", ErrInvalidParameter, s, AveragingMean, AveragingMedian, AveragingWeighted)
+	}
+}
+
+func (a Averaging) Validate() error {
+	if _, err := ParseAveragingMode(string(a.Mode)); err != nil {
+		return err
+	}
+	if a.Window < 1 || a.Window > MaxAveragingWindow {
+		return fmt.Errorf("%w: averaging window [%d] must be between 1 and %d", ErrInvalidParameter, a.Window, MaxAveragingWindow)
+	}
+	if _, err := ParseAveragingMethod(string(a.Method)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when no data matches the requested parameters.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidPeriod is returned when a year, quarter or period range is malformed or out of range.
+	ErrInvalidPeriod = errors.New("invalid period")
+	// ErrInvalidParameter is returned when a non-period parameter, such as the averaging method, is invalid.
+	ErrInvalidParameter = errors.New("invalid parameter")
+	// ErrUpstreamUnavailable is returned when the database or the cache cannot be reached.
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	// ErrConflict is returned when a record would clash with existing data, such as an alias
+	// that already resolves to another region.
+	ErrConflict = errors.New("conflict")
+)
+
+// FieldError reports which request field is invalid and why. It wraps one of the sentinel errors
+// above, so callers can still match the kind of problem with errors.Is.
+type FieldError struct {
+	Field  string
+	Reason string
+	Err    error
+}
+
+func NewFieldError(kind error, field string, reason string) *FieldError {
+	return &FieldError{Field: field, Reason: reason, Err: kind}
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
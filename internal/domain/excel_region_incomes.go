@@ -1,8 +1,63 @@
 package domain
 
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PeriodType tells whether a source value covers a quarter or a whole year.
+type PeriodType string
+
+const (
+	PeriodQuarter PeriodType = "quarter"
+	// PeriodYear is an annual total as published by the source, not an average of quarters.
+	PeriodYear PeriodType = "year"
+)
+
 type ExcelRegionIncome struct {
-	Region               string
-	Year                 int32
-	Quarter              int32
-	AverageRegionIncomes float32
+	// Sheet is the workbook sheet the value was read from.
+	Sheet  string
+	Region string
+	// RegionCode is the OKATO, OKTMO or ISO 3166-2:RU code of the region when the source
+	// identifies regions by code; the region is then resolved by it instead of by name.
+	RegionCode string
+	PeriodType PeriodType
+	Year       int32
+	// Quarter is 0 for PeriodYear values.
+	Quarter int32
+	// AverageRegionIncomes is zero and must not be used when Missing is set.
+	AverageRegionIncomes decimal.Decimal
+	// Missing is set when the source does not publish the value; MissingMarker is the cell
+	// text it used instead of a number, empty for an empty cell.
+	Missing       bool
+	MissingMarker string
+	// Source is the workbook row the value was parsed from; values of one row share it.
+	Source *SourceRow
+	// DownloadedAt is when the source file was downloaded; the value of a period from the latest
+	// download wins. A zero time is taken as the time of loading.
+	DownloadedAt time.Time
+}
+
+// missingMarkers are the cell values the source uses instead of a number: "…" for data that is
+// not available, "-" for no such phenomenon and "x" for values suppressed for confidentiality.
+// Dashes and the letter x are accepted in their typographic and Cyrillic spellings.
+var missingMarkers = map[string]bool{
+	"":    true,
+	"…":   true,
+	"...": true,
+	"-":   true,
+	"–":   true,
+	"—":   true,
+	"x":   true,
+	"X":   true,
+	"х":   true,
+	"Х":   true,
+}
+
+// IsMissingValue reports whether a source cell marks a missing or suppressed value rather than
+// holding a number.
+func IsMissingValue(cell string) bool {
+	return missingMarkers[strings.TrimSpace(cell)]
 }
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsMissingValue(t *testing.T) {
+	tests := []struct {
+		cell     string
+		expected bool
+	}{
+		{cell: "", expected: true},
+		{cell: " ", expected: true},
+		{cell: "…", expected: true},
+		{cell: "...", expected: true},
+		{cell: "-", expected: true},
+		{cell: "—", expected: true},
+		{cell: "x", expected: true},
+		{cell: "х", expected: true},
+		{cell: "0", expected: false},
+		{cell: "42750.5", expected: false},
+		{cell: "-5", expected: false},
+		{cell: "xx", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cell, func(t *testing.T) {
+			require.Equal(t, tt.expected, IsMissingValue(tt.cell))
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package domain
+
+import "time"
+
+// IngestionResult summarises one load of parsed rows into the database.
+type IngestionResult struct {
+	RowsRead int
+	// RowsInserted counts the quarterly values inserted.
+	RowsInserted int64
+	// AnnualRowsInserted counts the published annual totals inserted.
+	AnnualRowsInserted int64
+	// RowsRejected counts the source rows put into quarantine, whether they failed to parse or
+	// their region could not be resolved.
+	RowsRejected int
+	// UnresolvedRegions lists, sorted and without duplicates, the source names or codes that
+	// matched no region name, alias or code. Their rows are not loaded.
+	UnresolvedRegions []string
+}
+
+type IngestionRejectStatus string
+
+const (
+	IngestionRejectOpen     IngestionRejectStatus = "open"
+	IngestionRejectResolved IngestionRejectStatus = "resolved"
+	IngestionRejectAll      IngestionRejectStatus = "all"
+)
+
+func ParseIngestionRejectStatus(s string) (IngestionRejectStatus, error) {
+	switch status := IngestionRejectStatus(s); status {
+	case IngestionRejectOpen, IngestionRejectResolved, IngestionRejectAll:
+		return status, nil
+	default:
+		return "", NewFieldError(ErrInvalidParameter, "status", "unknown reject status ["+s+"], expected one of: open, resolved, all")
+	}
+}
+
+// IngestionReject is a source row kept in quarantine because it could not be loaded. A reject
+// is identified by its file, sheet and row; it is resolved once that row is loaded, either by a
+// later ingestion of the file or by re-processing the reject.
+type IngestionReject struct {
+	ID         int64
+	Row        *SourceRow
+	Reason     string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	ResolvedAt *time.Time
+}
+
+// ReprocessResult summarises a re-processing of quarantined rows.
+type ReprocessResult struct {
+	Reprocessed int
+	Resolved    int
+	Rejected    int
+	// NotOpen are the requested ids that are not open rejects: unknown or already resolved.
+	NotOpen []int64
+}
+
+type IngestionRunStatus string
+
+const (
+	IngestionRunRunning   IngestionRunStatus = "running"
+	IngestionRunSucceeded IngestionRunStatus = "succeeded"
+	IngestionRunFailed    IngestionRunStatus = "failed"
+	// IngestionRunSkipped marks a run that found the source unchanged and loaded nothing.
+	IngestionRunSkipped IngestionRunStatus = "skipped"
+)
+
+// IngestionSource describes the downloaded file an ingestion run works on. ETag and LastModified
+// are the validators the server sent with the file, for conditional requests on the next run.
+type IngestionSource struct {
+	URL          string
+	LocalPath    string
+	ETag         string
+	LastModified string
+	// NotModified is set when the server answered the conditional request with 304 Not Modified;
+	// nothing was downloaded and LocalPath is the file of the previous run.
+	NotModified bool
+	// Force loads the file even when its contents are those of the last successful run.
+	Force bool
+	// DownloadedAt is when the file was downloaded. The values it loads replace those of earlier
+	// downloads only, so an older file loaded later does not override newer figures.
+	DownloadedAt time.Time
+	// LastRun is the last successful run of the source, nil before its first one. The caller
+	// looks it up, as the download is conditional on it too; the file is compared with its hash.
+	LastRun *IngestionRun
+}
+
+// IngestionRun is the audit record of one reader run over a downloaded source file. A run that
+// is still Running has no FinishedAt; a Failed run keeps the counters it reached and the error.
+type IngestionRun struct {
+	ID           int64
+	StartedAt    time.Time
+	FinishedAt   *time.Time
+	SourceURL    string
+	LocalPath    string
+	ETag         string
+	LastModified string
+	// FileSHA256 is the hex encoded SHA-256 of the file contents.
+	FileSHA256 string
+	SheetCount int
+	RowsParsed int
+	// RowsInserted and AnnualRowsInserted count the quarterly values and the annual totals inserted.
+	RowsInserted       int64
+	AnnualRowsInserted int64
+	RowsRejected       int
+	// UnmatchedRegions lists the source region names or codes that matched no region, alias or code.
+	UnmatchedRegions []string
+	Status           IngestionRunStatus
+	Error            string
+	// SkipReason tells why a Skipped run loaded nothing.
+	SkipReason string
+}
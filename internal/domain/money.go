@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+type Rounding string
+
+const (
+	RoundingHalfUp   Rounding = "half_up"
+	RoundingHalfEven Rounding = "half_even"
+	RoundingDown     Rounding = "down"
+	RoundingUp       Rounding = "up"
+	RoundingCeil     Rounding = "ceil"
+	RoundingFloor    Rounding = "floor"
+)
+
+// MoneyFormat controls how exact money values are presented: how many decimal places they are
+// rounded to, with which rounding mode, and whether they are written as JSON strings or numbers.
+type MoneyFormat struct {
+	Scale    int32
+	Rounding Rounding
+	AsString bool
+}
+
+func DefaultMoneyFormat() MoneyFormat {
+	return MoneyFormat{Scale: 2, Rounding: RoundingHalfUp}
+}
+
+func ParseRounding(s string) (Rounding, error) {
+	switch rounding := Rounding(s); rounding {
+	case RoundingHalfUp, RoundingHalfEven, RoundingDown, RoundingUp, RoundingCeil, RoundingFloor:
+		return rounding, nil
+	default:
+		return "", fmt.Errorf("unknown rounding mode [%s], expected one of: %s, %s, %s, %s, %s, %s",
+			s, RoundingHalfUp, RoundingHalfEven, RoundingDown, RoundingUp, RoundingCeil, RoundingFloor)
+	}
+}
+
+// Round rounds d to the configured scale. Half-up rounds halves away from zero, half-even to
+// the nearest even digit, down and up towards and away from zero.
+func (f MoneyFormat) Round(d decimal.Decimal) decimal.Decimal {
+	switch f.Rounding {
+	case RoundingHalfEven:
+		return d.RoundBank(f.Scale)
+	case RoundingDown:
+		return d.RoundDown(f.Scale)
+	case RoundingUp:
+		return d.RoundUp(f.Scale)
+	case RoundingCeil:
+		return d.RoundCeil(f.Scale)
+	case RoundingFloor:
+		return d.RoundFloor(f.Scale)
+	default:
+		return d.Round(f.Scale)
+	}
+}
+
+// Format rounds d and renders it with exactly Scale decimal places.
+func (f MoneyFormat) Format(d decimal.Decimal) string {
+	return f.Round(d).StringFixed(f.Scale)
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+)
+
+// NumberLocale is the convention numbers are written in when a cell holds text instead of a
+// numeric value.
+type NumberLocale string
+
+const (
+	// NumberLocaleRU uses a decimal comma and groups thousands with spaces, including the
+	// non-breaking and thin ones. A decimal point is accepted too, as raw numeric cell values use it.
+	NumberLocaleRU NumberLocale = "ru"
+	// NumberLocaleEN uses a decimal point and groups thousands with commas or spaces.
+	NumberLocaleEN NumberLocale = "en"
+)
+
+func ParseNumberLocale(s string) (NumberLocale, error) {
+	switch locale := NumberLocale(s); locale {
+	case NumberLocaleRU, NumberLocaleEN:
+		return locale, nil
+	default:
+		return "", fmt.Errorf("%w: unknown number locale [%s], expected one of: %s, %s", ErrInvalidParameter, s, NumberLocaleRU, NumberLocaleEN)
+	}
+}
+
+// footnoteSuperscripts are footnote marks the source glues onto values, such as "42750,5¹".
+const footnoteSuperscripts = "⁰¹²³⁴⁵⁶⁷⁸⁹*"
+
+var (
+	// spacedFootnote is a footnote reference set apart from the value, such as "42750,5 1)".
+	spacedFootnote = regexp.MustCompile(`\s+\d{1,2}\)$`)
+	// gluedFootnote is a footnote reference written in plain digits right after the value, such
+	// as "42750,51)": its digits cannot be told apart from the value's.
+	gluedFootnote = regexp.MustCompile(`\d\)$`)
+)
+
+// ParseNumber reads a number written in the locale. Footnote marks are dropped. Input that
+// could mean different numbers, such as "42,750" in the ru locale or "42,75" in the en one, is
+// rejected instead of being read one way or the other.
+func (l NumberLocale) ParseNumber(cell string) (decimal.Decimal, error) {
+	s := strings.TrimSpace(cell)
+	s = strings.TrimSpace(strings.TrimRight(s, footnoteSuperscripts))
+	s = spacedFootnote.ReplaceAllString(s, "")
+	if gluedFootnote.MatchString(s) {
+		return decimal.Decimal{}, fmt.Errorf("number [%s] has a footnote reference glued to its digits", cell)
+	}
+
+	sign := ""
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = "-", rest
+	} else if rest, ok := strings.CutPrefix(s, "−"); ok {
+		sign, s = "-", rest
+	}
+
+	for _, r := range s {
+		if !unicode.IsDigit(r) && !unicode.IsSpace(r) && r != ',' && r != '.' {
+			return decimal.Decimal{}, fmt.Errorf("number [%s] has an unexpected character [%c]", cell, r)
+		}
+	}
+
+	decimalMark := "."
+	groupSeparator := ""
+	switch l {
+	case NumberLocaleRU:
+		if strings.Contains(s, ",") && strings.Contains(s, ".") {
+			return decimal.Decimal{}, fmt.Errorf("number [%s] has both a decimal comma and a decimal point", cell)
+		}
+		if strings.Contains(s, ",") {
+			decimalMark = ","
+		}
+	case NumberLocaleEN:
+		groupSeparator = ","
+	default:
+		return decimal.Decimal{}, fmt.Errorf("%w: unknown number locale [%s]", ErrInvalidParameter, l)
+	}
+	if strings.Count(s, decimalMark) > 1 {
+		return decimal.Decimal{}, fmt.Errorf("number [%s] has more than one decimal mark", cell)
+	}
+
+	integerPart, fraction, hasFraction := strings.Cut(s, decimalMark)
+	if hasFraction && (fraction == "" || strings.IndexFunc(fraction, isNotDigit) >= 0) {
+		return decimal.Decimal{}, fmt.Errorf("number [%s] has an invalid fractional part", cell)
+	}
+
+	groups := strings.FieldsFunc(integerPart, func(r rune) bool {
+		return unicode.IsSpace(r) || (groupSeparator != "" && strings.ContainsRune(groupSeparator, r))
+	})
+	if len(groups) == 0 {
+		return decimal.Decimal{}, fmt.Errorf("number [%s] has no digits before the decimal mark", cell)
+	}
+	if len(groups) > 1 || strings.IndexFunc(integerPart, isNotDigit) >= 0 {
+		for idx, group := range groups {
+			if strings.IndexFunc(group, isNotDigit) >= 0 || (idx == 0 && len(group) > 3) || (idx > 0 && len(group) != 3) {
+				return decimal.Decimal{}, fmt.Errorf("number [%s] has digit groups that are not thousands", cell)
+			}
+		}
+	}
+	digits := strings.Join(groups, "")
+
+	// A ru number never groups thousands with a comma, but files mixing conventions do. A point
+	// is always decimal: it is what raw numeric values are written with.
+	if decimalMark == "," && len(fraction) == 3 && len(groups) == 1 && digits != "0" {
+		return decimal.Decimal{}, fmt.Errorf("number [%s] is ambiguous: the comma may separate thousands", cell)
+	}
+
+	number := sign + digits
+	if hasFraction {
+		number += "." + fraction
+	}
+	value, err := decimal.NewFromString(number)
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("failed to parse number [%s]: %w", cell, err)
+	}
+	return value, nil
+}
+
+func isNotDigit(r rune) bool {
+	return !unicode.IsDigit(r)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumberLocaleParseNumber(t *testing.T) {
+	tests := []struct {
+		locale   NumberLocale
+		cell     string
+		expected string
+		wantErr  bool
+	}{
+		{locale: NumberLocaleRU, cell: "42750,5", expected: "42750.5"},
+		{locale: NumberLocaleRU, cell: "42 750,5", expected: "42750.5"},
+		{locale: NumberLocaleRU, cell: "42\u00a0750,50", expected: "42750.5"},
+		{locale: NumberLocaleRU, cell: "42\u2009750,5", expected: "42750.5"},
+		{locale: NumberLocaleRU, cell: "42\u202f750,5", expected: "42750.5"},
+		{locale: NumberLocaleRU, cell: "1 042\u202f750", expected: "1042750"},
+		{locale: NumberLocaleRU, cell: "42750.5", expected: "42750.5"},
+		{locale: NumberLocaleRU, cell: "36587.160000000003", expected: "36587.160000000003"},
+		{locale: NumberLocaleRU, cell: "42750,5¹", expected: "42750.5"},
+		{locale: NumberLocaleRU, cell: "42750,5 2)", expected: "42750.5"},
+		{locale: NumberLocaleRU, cell: "−12,5", expected: "-12.5"},
+		{locale: NumberLocaleRU, cell: "0,125", expected: "0.125"},
+		{locale: NumberLocaleRU, cell: "42,750", wantErr: true},
+		{locale: NumberLocaleRU, cell: "42750.125", expected: "42750.125"},
+		{locale: NumberLocaleRU, cell: "42,750.5", wantErr: true},
+		{locale: NumberLocaleRU, cell: "42 75,5", wantErr: true},
+		{locale: NumberLocaleRU, cell: "42750,51)", wantErr: true},
+		{locale: NumberLocaleRU, cell: "42750,5 руб.", wantErr: true},
+		{locale: NumberLocaleRU, cell: ",5", wantErr: true},
+		{locale: NumberLocaleEN, cell: "42,750.5", expected: "42750.5"},
+		{locale: NumberLocaleEN, cell: "42 750.5", expected: "42750.5"},
+		{locale: NumberLocaleEN, cell: "42.750", expected: "42.75"},
+		{locale: NumberLocaleEN, cell: "42,75", wantErr: true},
+		{locale: NumberLocaleEN, cell: "4,27,50", wantErr: true},
+		{locale: NumberLocaleEN, cell: "42.750.5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.locale)+" "+tt.cell, func(t *testing.T) {
+			value, err := tt.locale.ParseNumber(tt.cell)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, decimal.RequireFromString(tt.expected).Equal(value), "got %s", value)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// RegionAlias is an alternative spelling of a region name in the source files, such as
+// "Москва" for "г.Москва". Aliases are compared in the NormalizeRegionName form.
+type RegionAlias struct {
+	ID        int32     `json:"id" db:"id"`
+	RegionId  int32     `json:"RegionId" db:"region_id"`
+	Alias     string    `json:"Alias" db:"alias"`
+	CreatedAt time.Time `json:"CreatedAt" db:"created_at"`
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type RegionAnnualIncome struct {
+	RegionId int32           `db:"region_id" json:"RegionId"`
+	Year     int32           `db:"year" json:"Year"`
+	Value    decimal.Decimal `db:"value" json:"Value"`
+	LoadedAt time.Time       `db:"loaded_at" json:"LoadedAt"`
+	// DownloadedAt is when the source file of the total was downloaded.
+	DownloadedAt time.Time `db:"downloaded_at" json:"-"`
+}
+
+// AnnualTotalMismatch is a published annual total that differs from the mean of the four
+// quarterly values of the same sheet, region and year by more than the tolerance.
+type AnnualTotalMismatch struct {
+	Sheet       string
+	Region      string
+	Year        int32
+	Annual      decimal.Decimal
+	QuarterMean decimal.Decimal
+	// DeviationPercent is |QuarterMean - Annual| / Annual, in percent.
+	DeviationPercent decimal.Decimal
+}
+
+// CheckAnnualTotals compares every annual total with the mean of its four quarters. Years with
+// fewer than four published quarters or a missing or zero annual total are not checked.
+func CheckAnnualTotals(incomes []*ExcelRegionIncome, tolerancePercent decimal.Decimal) []AnnualTotalMismatch {
+	type periodKey struct {
+		sheet  string
+		region string
+		year   int32
+	}
+	type yearValues struct {
+		annual   *decimal.Decimal
+		quarters []decimal.Decimal
+	}
+
+	keys := make([]periodKey, 0)
+	values := make(map[periodKey]*yearValues)
+	for _, income := range incomes {
+		if income.Missing {
+			continue
+		}
+		key := periodKey{income.Sheet, income.Region, income.Year}
+		if values[key] == nil {
+			values[key] = &yearValues{}
+			keys = append(keys, key)
+		}
+		if income.PeriodType == PeriodYear {
+			values[key].annual = &income.AverageRegionIncomes
+		} else {
+			values[key].quarters = append(values[key].quarters, income.AverageRegionIncomes)
+		}
+	}
+
+	hundred := decimal.NewFromInt(100)
+	mismatches := make([]AnnualTotalMismatch, 0)
+	for _, key := range keys {
+		year := values[key]
+		if year.annual == nil || year.annual.IsZero() || len(year.quarters) != 4 {
+			continue
+		}
+		quarterMean := decimal.Sum(year.quarters[0], year.quarters[1:]...).Div(decimal.NewFromInt(4))
+		deviation := quarterMean.Sub(*year.annual).Abs().Div(year.annual.Abs()).Mul(hundred)
+		if deviation.GreaterThan(tolerancePercent) {
+			mismatches = append(mismatches, AnnualTotalMismatch{
+				Sheet:            key.sheet,
+				Region:           key.region,
+				Year:             key.year,
+				Annual:           *year.annual,
+				QuarterMean:      quarterMean,
+				DeviationPercent: deviation,
+			})
+		}
+	}
+	return mismatches
+}
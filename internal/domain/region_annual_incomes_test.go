@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAnnualTotals(t *testing.T) {
+	incomes := func(region string, annual int64, quarters ...int64) []*ExcelRegionIncome {
+		values := make([]*ExcelRegionIncome, 0, len(quarters)+1)
+		for idx, quarter := range quarters {
+			values = append(values, &ExcelRegionIncome{
+				Sheet: "2024", Region: region, PeriodType: PeriodQuarter, Year: 2024, Quarter: int32(idx + 1),
+				AverageRegionIncomes: decimal.NewFromInt(quarter),
+			})
+		}
+		return append(values, &ExcelRegionIncome{
+			Sheet: "2024", Region: region, PeriodType: PeriodYear, Year: 2024,
+			AverageRegionIncomes: decimal.NewFromInt(annual),
+		})
+	}
+
+	var all []*ExcelRegionIncome
+	all = append(all, incomes("Республика Башкортостан", 42750, 38000, 41000, 42000, 50000)...)
+	all = append(all, incomes("Кемеровская область", 45000, 38000, 41000, 42000, 50000)...)
+	all = append(all, incomes("Неполный год", 1000, 38000, 41000)...)
+	withMissingQuarter := incomes("Скрытый квартал", 1000, 38000, 41000, 42000, 0)
+	withMissingQuarter[3].Missing = true
+	all = append(all, withMissingQuarter...)
+
+	mismatches := CheckAnnualTotals(all, decimal.NewFromInt(1))
+	require.Len(t, mismatches, 1)
+	require.Equal(t, "Кемеровская область", mismatches[0].Region)
+	require.Equal(t, int32(2024), mismatches[0].Year)
+	require.True(t, decimal.NewFromInt(42750).Equal(mismatches[0].QuarterMean))
+	require.True(t, decimal.NewFromInt(5).Equal(mismatches[0].DeviationPercent))
+}
@@ -1,9 +1,20 @@
 package domain
 
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
 type RegionIncomes struct {
-	ID       int32   `json:"id" db:"id"`
-	RegionId int32   `json:"RegionId" db:"region_id"`
-	Year     int32   `json:"Year" db:"year"`
-	Quarter  int32   `json:"Quarter" db:"quarter"`
-	Value    float32 `json:"Value" db:"value"`
+	ID       int32 `json:"id" db:"id"`
+	RegionId int32 `json:"RegionId" db:"region_id"`
+	Year     int32 `json:"Year" db:"year"`
+	Quarter  int32 `json:"Quarter" db:"quarter"`
+	// Value is null when the source did not publish the quarter; MissingMarker then holds the
+	// cell text the source used instead.
+	Value         decimal.NullDecimal `json:"Value" db:"value"`
+	MissingMarker *string             `json:"MissingMarker" db:"missing_marker"`
+	// DownloadedAt is when the source file of the value was downloaded.
+	DownloadedAt time.Time `json:"-" db:"downloaded_at"`
 }
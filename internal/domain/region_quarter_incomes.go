@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type RegionQuarterIncome struct {
+	RegionId int32 `db:"region_id" json:"RegionId"`
+	Year     int32 `db:"year" json:"Year"`
+	Quarter  int32 `db:"quarter" json:"Quarter"`
+	// Value is null for a quarter the source did not publish.
+	Value    decimal.NullDecimal `db:"value" json:"Value"`
+	LoadedAt time.Time           `db:"loaded_at" json:"LoadedAt"`
+}
@@ -1,7 +1,69 @@
 package domain
 
+import (
+	"regexp"
+	"strings"
+)
+
 type Regions struct {
 	ID         string `json:"id" db:"id"`
 	RegionId   int32  `json:"RegionId" db:"region_id"`
 	RegionName string `json:"RegionName" db:"region_name"`
+	OkatoCode  string `json:"OkatoCode" db:"okato_code"`
+	OktmoCode  string `json:"OktmoCode" db:"oktmo_code"`
+	IsoCode    string `json:"IsoCode" db:"iso_code"`
+}
+
+// footnoteMarker matches footnote references such as "1)", "2)" or superscript digits that
+// Rosstat appends to region names.
+var footnoteMarker = regexp.MustCompile(`\d+\)|[¹²³⁴⁵⁶⁷⁸⁹⁰*]+`)
+
+// regionNameReplacer folds the spelling variants that do not distinguish region names: ё/е and
+// the different dashes and hyphens, which are dropped.
+var regionNameReplacer = strings.NewReplacer(
+	"ё", "е",
+	"-", "",
+	"‐", "",
+	"‑", "",
+	"‒", "",
+	"–", "",
+	"—", "",
+	"―", "",
+	"−", "",
+)
+
+// NormalizeRegionName reduces a region name to the form used to compare names coming from
+// different sources: lower case, ё spelled as е, without footnote markers, dashes and whitespace.
+func NormalizeRegionName(name string) string {
+	name = footnoteMarker.ReplaceAllString(name, "")
+	name = regionNameReplacer.Replace(strings.ToLower(name))
+	return strings.Join(strings.Fields(name), "")
+}
+
+// NormalizeRegionCode reduces a region code to the form used for comparison: upper case,
+// without whitespace and, for numeric OKATO/OKTMO codes, without the trailing zeros of the
+// lower classification levels, so "80", "80000000" and "80 000 000 000" are the same code.
+func NormalizeRegionCode(code string) string {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(code), ""))
+	if normalized != "" && strings.Trim(normalized, "0123456789") == "" {
+		if trimmed := strings.TrimRight(normalized, "0"); len(trimmed) >= 2 {
+			return trimmed
+		}
+		return normalized[:min(2, len(normalized))]
+	}
+	return normalized
+}
+
+// HasCode reports whether code is the region's OKATO, OKTMO or ISO 3166-2:RU code.
+func (r *Regions) HasCode(code string) bool {
+	normalized := NormalizeRegionCode(code)
+	if normalized == "" {
+		return false
+	}
+	for _, regionCode := range []string{r.OkatoCode, r.OktmoCode, r.IsoCode} {
+		if regionCode != "" && NormalizeRegionCode(regionCode) == normalized {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeRegionName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "г.Москва", expected: "г.москва"},
+		{name: "г. Москва", expected: "г.москва"},
+		{name: "Республика  Северная Осетия - Алания", expected: "республикасевернаяосетияалания"},
+		{name: "Республика Северная Осетия–Алания", expected: "республикасевернаяосетияалания"},
+		{name: "Орловская область1)", expected: "орловскаяобласть"},
+		{name: "Орловская область 2)", expected: "орловскаяобласть"},
+		{name: "Орловская область¹", expected: "орловскаяобласть"},
+		{name: "Кемеровская область - Кузбасс", expected: "кемеровскаяобластькузбасс"},
+		{name: "Республика Саха (Якутия)", expected: "республикасаха(якутия)"},
+		{name: "Ставропольский КРАЙ", expected: "ставропольскийкрай"},
+		{name: "Чувашская Республика - Чувашия", expected: "чувашскаяреспубликачувашия"},
+		{name: "Ёлкинская область", expected: "елкинскаяобласть"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, NormalizeRegionName(tt.name))
+		})
+	}
+}
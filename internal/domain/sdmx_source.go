@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// SDMXSource describes an SDMX-ML feed of the indicator, such as the one of the EMISS/fedstat
+// portal. Series are identified by their key: the region is the value of RegionDimension, an
+// official region code, and the period is the observation time, optionally refined by
+// PeriodDimension.
+type SDMXSource struct {
+	// URL is where the message is downloaded from.
+	URL string
+	// FileName is the name of the local copy; it gets the download date like the workbook does.
+	FileName string
+	// RegionDimension holds the OKATO, OKTMO or ISO 3166-2:RU code of the region of a series.
+	RegionDimension string
+	// TimeDimension holds the period of an observation: "2024-Q1" for a quarter, "2024" or
+	// "2024-A1" for a year.
+	TimeDimension string
+	// PeriodDimension, when set, names the quarter of a yearly time period the way fedstat does,
+	// such as "I квартал", or "год" for the annual total.
+	PeriodDimension string
+	// SeriesFilter keeps only the series whose key has these dimension values, for messages
+	// that carry other measures or units of the indicator as well.
+	SeriesFilter map[string]string
+}
+
+// DefaultSDMXSource is the source with the dimension names of the fedstat messages. Its URL is
+// left for the configuration to set.
+func DefaultSDMXSource() SDMXSource {
+	return SDMXSource{
+		FileName:        "sdmx.xml",
+		RegionDimension: "OKATO",
+		TimeDimension:   "TIME_PERIOD",
+	}
+}
+
+func (s SDMXSource) Validate() error {
+	if _, err := url.ParseRequestURI(s.URL); err != nil {
+		return fmt.Errorf("%w: sdmx url [%s]: %w", ErrInvalidParameter, s.URL, err)
+	}
+	if s.FileName == "" {
+		return fmt.Errorf("%w: sdmx file name is not set", ErrInvalidParameter)
+	}
+	if s.RegionDimension == "" {
+		return fmt.Errorf("%w: sdmx region dimension is not set", ErrInvalidParameter)
+	}
+	if s.TimeDimension == "" {
+		return fmt.Errorf("%w: sdmx time dimension is not set", ErrInvalidParameter)
+	}
+	return nil
+}
+
+// MatchesSeries tells whether a series with the given key is selected by SeriesFilter.
+func (s SDMXSource) MatchesSeries(key map[string]string) bool {
+	for dimension, value := range s.SeriesFilter {
+		if key[dimension] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseSDMXSeriesFilter reads a series filter written as "FREQ=Q,UNIT=RUB".
+func ParseSDMXSeriesFilter(s string) (map[string]string, error) {
+	filter := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		dimension, value, ok := strings.Cut(pair, "=")
+		dimension, value = strings.TrimSpace(dimension), strings.TrimSpace(value)
+		if !ok || dimension == "" {
+			return nil, fmt.Errorf("%w: sdmx series filter [%s] must be DIMENSION=VALUE", ErrInvalidParameter, pair)
+		}
+		filter[dimension] = value
+	}
+	return filter, nil
+}
+
+// SeriesFilterString writes SeriesFilter back in the form ParseSDMXSeriesFilter reads.
+func (s SDMXSource) SeriesFilterString() string {
+	pairs := make([]string, 0, len(s.SeriesFilter))
+	for dimension, value := range s.SeriesFilter {
+		pairs = append(pairs, dimension+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var columnNameRegexp = regexp.MustCompile(`^[A-Z]{1,3}$`)
+
+// SheetLayout describes where the values of a source sheet are, so that a layout change in the
+// source is caught by validation instead of shifting data. Rows are 1-based and columns are
+// letters, as in the workbook.
+type SheetLayout struct {
+	// HeaderRow holds the period headers. A period header cell names the year of the columns from
+	// it up to the next period header, as a merged "2019 год" cell does.
+	HeaderRow int
+	// SubHeaderRow names the columns under each period header: the quarters and the annual total.
+	SubHeaderRow int
+	// FirstDataRow is the first row with region values.
+	FirstDataRow int
+	// RegionColumn holds the region names.
+	RegionColumn string
+	// PeriodPattern matches a period header cell; its first group is the year.
+	PeriodPattern *regexp.Regexp
+	// AnnualPattern matches the sub-header of the annual total column of a period.
+	AnnualPattern *regexp.Regexp
+	// QuarterPattern matches the sub-header of a quarter column; its first group is the quarter
+	// number, in roman (I to IV) or arabic (1 to 4) digits. Every other non-empty sub-header of
+	// a period fails the sheet.
+	QuarterPattern *regexp.Regexp
+	// FooterRows is the number of trailing rows of the sheet, such as notes, that are not data.
+	FooterRows int
+	// FooterPattern, when set, ends the data at the first row whose region cell matches it.
+	FooterPattern *regexp.Regexp
+	// IncludeSheets and ExcludeSheets select the sheets to read by name or by a glob pattern
+	// such as "20*". With no IncludeSheets every sheet is included; ExcludeSheets wins over it.
+	IncludeSheets []string
+	ExcludeSheets []string
+	// NumberLocale is how values stored as text are written. Numeric cells are read as their raw
+	// value and do not depend on it.
+	NumberLocale NumberLocale
+}
+
+// DefaultSheetLayout is the layout of the Rosstat income workbook: two title rows, the year
+// headers with the quarter names under them, and four rows of notes at the bottom.
+func DefaultSheetLayout() SheetLayout {
+	return SheetLayout{
+		HeaderRow:      3,
+		SubHeaderRow:   4,
+		FirstDataRow:   5,
+		RegionColumn:   "A",
+		PeriodPattern:  regexp.MustCompile(`(\d{4})\s+год`),
+		AnnualPattern:  regexp.MustCompile(`год`),
+		QuarterPattern: regexp.MustCompile(`(?i)^(IV|I{1,3}|[1-4])\s*квартал`),
+		FooterRows:     4,
+		NumberLocale:   NumberLocaleRU,
+	}
+}
+
+func (l SheetLayout) Validate() error {
+	if l.HeaderRow < 1 {
+		return fmt.Errorf("%w: header row [%d] must be positive", ErrInvalidParameter, l.HeaderRow)
+	}
+	if l.SubHeaderRow <= l.HeaderRow {
+		return fmt.Errorf("%w: sub-header row [%d] must be below header row [%d]", ErrInvalidParameter, l.SubHeaderRow, l.HeaderRow)
+	}
+	if l.FirstDataRow <= l.SubHeaderRow {
+		return fmt.Errorf("%w: first data row [%d] must be below sub-header row [%d]", ErrInvalidParameter, l.FirstDataRow, l.SubHeaderRow)
+	}
+	if !columnNameRegexp.MatchString(l.RegionColumn) {
+		return fmt.Errorf("%w: region column [%s] must be a column letter such as A", ErrInvalidParameter, l.RegionColumn)
+	}
+	if l.PeriodPattern == nil || l.PeriodPattern.NumSubexp() < 1 {
+		return fmt.Errorf("%w: period pattern must capture the year in its first group", ErrInvalidParameter)
+	}
+	if l.AnnualPattern == nil {
+		return fmt.Errorf("%w: annual column pattern is not set", ErrInvalidParameter)
+	}
+	if l.QuarterPattern == nil || l.QuarterPattern.NumSubexp() < 1 {
+		return fmt.Errorf("%w: quarter column pattern must capture the quarter number in its first group", ErrInvalidParameter)
+	}
+	if l.FooterRows < 0 {
+		return fmt.Errorf("%w: footer rows [%d] must not be negative", ErrInvalidParameter, l.FooterRows)
+	}
+	if _, err := ParseNumberLocale(string(l.NumberLocale)); err != nil {
+		return err
+	}
+	for _, pattern := range append(append([]string{}, l.IncludeSheets...), l.ExcludeSheets...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: sheet pattern [%s]: %w", ErrInvalidParameter, pattern, err)
+		}
+	}
+	return nil
+}
+
+// SelectsSheet tells whether the sheet is read according to IncludeSheets and ExcludeSheets.
+func (l SheetLayout) SelectsSheet(sheet string) bool {
+	matchesAny := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, sheet); matched {
+				return true
+			}
+		}
+		return false
+	}
+	if len(l.IncludeSheets) > 0 && !matchesAny(l.IncludeSheets) {
+		return false
+	}
+	return !matchesAny(l.ExcludeSheets)
+}
+
+var romanQuarters = map[string]int{"I": 1, "II": 2, "III": 3, "IV": 4}
+
+// QuarterNumber reads the quarter number from the sub-header of a quarter column. It reports
+// false when the sub-header does not match QuarterPattern or names no quarter from 1 to 4.
+func (l SheetLayout) QuarterNumber(subHeader string) (int, bool) {
+	match := l.QuarterPattern.FindStringSubmatch(subHeader)
+	if match == nil {
+		return 0, false
+	}
+	number := strings.ToUpper(strings.TrimSpace(match[1]))
+	if quarter, ok := romanQuarters[number]; ok {
+		return quarter, true
+	}
+	quarter, err := strconv.Atoi(number)
+	if err != nil || quarter < 1 || quarter > 4 {
+		return 0, false
+	}
+	return quarter, true
+}
@@ -0,0 +1,21 @@
+package domain
+
+// SourceRow is a data row of a source workbook as read, before parsing. Header is the period
+// header of its sheet, so the row can be parsed again on its own.
+type SourceRow struct {
+	File      string
+	Sheet     string
+	RowNumber int
+	Header    []string
+	Cells     []string
+}
+
+// ParsedFile is the result of reading a source workbook: the values that were parsed and the
+// rows that could not be. RowCount counts the data rows read from all SheetCount sheets.
+type ParsedFile struct {
+	Path       string
+	SheetCount int
+	RowCount   int
+	Incomes    []*ExcelRegionIncome
+	Rejects    []*IngestionReject
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// YearQuarter identifies a calendar quarter, written as "YYYY.Q" (e.g. "2025.2").
+// The zero value means the period is not set.
+type YearQuarter struct {
+	Year    int32
+	Quarter int32
+}
+
+func ParseYearQuarter(s string) (YearQuarter, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 2 {
+		return YearQuarter{}, fmt.Errorf("%w: invalid period format [%s], expected YYYY.Q", ErrInvalidPeriod, s)
+	}
+
+	year, err := strconv.ParseInt(parts[0], 10, 32)
+	if err != nil {
+		return YearQuarter{}, fmt.Errorf("%w: failed to parse year of period [%s]: %w", ErrInvalidPeriod, s, err)
+	}
+
+	quarter, err := strconv.ParseInt(parts[1], 10, 32)
+	if err != nil {
+		return YearQuarter{}, fmt.Errorf("%w: failed to parse quarter of period [%s]: %w", ErrInvalidPeriod, s, err)
+	}
+	if quarter < 1 || quarter > 4 {
+		return YearQuarter{}, fmt.Errorf("%w: quarter of period [%s] must be between 1 and 4", ErrInvalidPeriod, s)
+	}
+
+	return YearQuarter{Year: int32(year), Quarter: int32(quarter)}, nil
+}
+
+func (yq YearQuarter) IsZero() bool {
+	return yq.Year == 0 && yq.Quarter == 0
+}
+
+func (yq YearQuarter) Before(other YearQuarter) bool {
+	return yq.Year < other.Year || (yq.Year == other.Year && yq.Quarter < other.Quarter)
+}
+
+func (yq YearQuarter) String() string {
+	return fmt.Sprintf("%d.%d", yq.Year, yq.Quarter)
+}
@@ -0,0 +1,11 @@
+package domain
+
+// YearRange is the span of years for which incomes are loaded.
+type YearRange struct {
+	MinYear int32 `db:"min_year" json:"MinYear"`
+	MaxYear int32 `db:"max_year" json:"MaxYear"`
+}
+
+func (yr YearRange) Contains(year int32) bool {
+	return year >= yr.MinYear && year <= yr.MaxYear
+}
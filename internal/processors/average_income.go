@@ -11,13 +11,22 @@ import (
 
 //go:generate mockgen -destination=./mocks/average_income_db_repository.go -package=mocks -mock_names=AverageIncomeDBRepository=AverageIncomeDBRepository . AverageIncomeDBRepository
 type AverageIncomeDBRepository interface {
-	GetRegionIncomes(ctx context.Context, regionId int32, year int32, quarter int32) (*domain.AverageRegionIncomes, error)
+	GetRegionIncomes(ctx context.Context, regionIds []int32, year int32, quarter int32, averaging domain.Averaging) ([]*domain.AverageRegionIncomes, error)
+	GetRegionQuarterIncomes(ctx context.Context, regionId int32, from domain.YearQuarter, to domain.YearQuarter) ([]*domain.RegionQuarterIncome, error)
+	GetRegionAnnualIncomes(ctx context.Context, regionId int32, fromYear int32, toYear int32) ([]*domain.RegionAnnualIncome, error)
+	GetLoadedYearRange(ctx context.Context) (*domain.YearRange, error)
 }
 
 //go:generate mockgen -destination=./mocks/average_income_redis_repository.go -package=mocks -mock_names=AverageIncomeRedisRepository=AverageIncomeRedisRepository . AverageIncomeRedisRepository
 type AverageIncomeRedisRepository interface {
-	GetCachedRegionIncomes(ctx context.Context, regionId int32, year int32, quarter int32) (*domain.AverageRegionIncomes, error)
-	SetCachedRegionIncomes(ctx context.Context, averageRegionIncomes *domain.AverageRegionIncomes, regionId int32, year int32, quarter int32) error
+	GetCachedRegionIncomes(ctx context.Context, regionIds []int32, year int32, quarter int32, averaging domain.Averaging) ([]*domain.AverageRegionIncomes, error)
+	SetCachedRegionIncomes(ctx context.Context, averageRegionIncomes []*domain.AverageRegionIncomes, regionIds []int32, year int32, quarter int32, averaging domain.Averaging) error
+	GetCachedRegionQuarterIncomes(ctx context.Context, regionId int32, from domain.YearQuarter, to domain.YearQuarter) ([]*domain.RegionQuarterIncome, error)
+	SetCachedRegionQuarterIncomes(ctx context.Context, regionQuarterIncomes []*domain.RegionQuarterIncome, regionId int32, from domain.YearQuarter, to domain.YearQuarter) error
+	GetCachedRegionAnnualIncomes(ctx context.Context, regionId int32, fromYear int32, toYear int32) ([]*domain.RegionAnnualIncome, error)
+	SetCachedRegionAnnualIncomes(ctx context.Context, regionAnnualIncomes []*domain.RegionAnnualIncome, regionId int32, fromYear int32, toYear int32) error
+	GetCachedYearRange(ctx context.Context) (*domain.YearRange, error)
+	SetCachedYearRange(ctx context.Context, yearRange *domain.YearRange) error
 }
 
 //go:generate mockgen -destination=./mocks/average_income_logger.go -package=mocks -mock_names=AverageIncomeLogger=AverageIncomeLogger . AverageIncomeLogger
@@ -36,25 +45,192 @@ func NewAverageIncome(averageIncomeRepository AverageIncomeDBRepository, average
 	return &averageIncome{averageIncomeRepository, averageIncomeRedisRepository, log}
 }
 
-func (a *averageIncome) GetRegionIncomes(ctx context.Context, regionId int32, year int32, quarter int32) (*domain.AverageRegionIncomes, error) {
-	cachedRegionIncomes, err := a.averageIncomeRedisRepository.GetCachedRegionIncomes(ctx, regionId, year, quarter)
+// GetRegionIncomes returns average incomes for the requested regions, or for every region
+// when regionIds is empty. Cached entries are served from Redis and only the missing regions
+// are fetched from the database, in a single query. When none of the missing regions has data,
+// the cached entries are returned alone, as the same request answers with an uncached batch.
+func (a *averageIncome) GetRegionIncomes(ctx context.Context, regionIds []int32, year int32, quarter int32, averaging domain.Averaging) ([]*domain.AverageRegionIncomes, error) {
+	if err := averaging.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid averaging parameters: %w", err)
+	}
+	if averaging.Mode == domain.AveragingCalendar {
+		// The window does not apply to a calendar year; keep it fixed so cache keys do not multiply.
+		averaging.Window = averaging.ExpectedQuarters()
+		if year == 0 {
+			return nil, domain.NewFieldError(domain.ErrInvalidPeriod, "year", "calendar mode requires year")
+		}
+		if quarter != 0 {
+			return nil, domain.NewFieldError(domain.ErrInvalidPeriod, "quarter", "quarter cannot be used in calendar mode")
+		}
+	}
+	if err := a.validatePeriod(ctx, year, quarter); err != nil {
+		return nil, err
+	}
+
+	cachedRegionIncomes, err := a.averageIncomeRedisRepository.GetCachedRegionIncomes(ctx, regionIds, year, quarter, averaging)
 	if err != nil && !errors.Is(err, redis.Nil) {
 		return nil, fmt.Errorf("it is impossible to get a cached region incomes: %w", err)
 	}
 
-	if cachedRegionIncomes == nil {
-		regionIncomes, err := a.averageIncomeRepository.GetRegionIncomes(ctx, regionId, year, quarter)
-		if err != nil {
-			a.logger.Error("it is impossible to get a region incomes", slog.String("err", err.Error()))
-			return nil, fmt.Errorf("it is impossible to get a region incomes, err: %s", err.Error())
+	if len(regionIds) == 0 {
+		if cachedRegionIncomes != nil {
+			return cachedRegionIncomes, nil
 		}
-		err = a.averageIncomeRedisRepository.SetCachedRegionIncomes(ctx, regionIncomes, regionId, year, quarter)
-		if err != nil {
-			a.logger.Error("it is impossible to set cached region incomes", slog.String("err", err.Error()))
-			return nil, fmt.Errorf("it is impossible to set cached region incomes: %w", err)
+		return a.getAndCacheRegionIncomes(ctx, regionIds, year, quarter, averaging)
+	}
+
+	missingRegionIds := missingRegionIds(regionIds, cachedRegionIncomes)
+	if len(missingRegionIds) == 0 {
+		return orderByRegionIds(regionIds, cachedRegionIncomes), nil
+	}
+
+	regionIncomes, err := a.getAndCacheRegionIncomes(ctx, missingRegionIds, year, quarter, averaging)
+	if errors.Is(err, domain.ErrNotFound) && len(cachedRegionIncomes) > 0 {
+		return orderByRegionIds(regionIds, cachedRegionIncomes), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return orderByRegionIds(regionIds, append(cachedRegionIncomes, regionIncomes...)), nil
+}
+
+func (a *averageIncome) getAndCacheRegionIncomes(ctx context.Context, regionIds []int32, year int32, quarter int32, averaging domain.Averaging) ([]*domain.AverageRegionIncomes, error) {
+	regionIncomes, err := a.averageIncomeRepository.GetRegionIncomes(ctx, regionIds, year, quarter, averaging)
+	if err != nil {
+		a.logger.Error("it is impossible to get a region incomes", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to get a region incomes: %w", err)
+	}
+	err = a.averageIncomeRedisRepository.SetCachedRegionIncomes(ctx, regionIncomes, regionIds, year, quarter, averaging)
+	if err != nil {
+		a.logger.Error("it is impossible to set cached region incomes", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to set cached region incomes: %w", err)
+	}
+	return regionIncomes, nil
+}
+
+// GetRegionQuarterIncomes returns the quarterly values of a region between from and to, without averaging.
+func (a *averageIncome) GetRegionQuarterIncomes(ctx context.Context, regionId int32, from domain.YearQuarter, to domain.YearQuarter) ([]*domain.RegionQuarterIncome, error) {
+	cachedQuarterIncomes, err := a.averageIncomeRedisRepository.GetCachedRegionQuarterIncomes(ctx, regionId, from, to)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("it is impossible to get a cached quarter incomes: %w", err)
+	}
+	if cachedQuarterIncomes != nil {
+		return cachedQuarterIncomes, nil
+	}
+
+	quarterIncomes, err := a.averageIncomeRepository.GetRegionQuarterIncomes(ctx, regionId, from, to)
+	if err != nil {
+		a.logger.Error("it is impossible to get a quarter incomes", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to get a quarter incomes: %w", err)
+	}
+	err = a.averageIncomeRedisRepository.SetCachedRegionQuarterIncomes(ctx, quarterIncomes, regionId, from, to)
+	if err != nil {
+		a.logger.Error("it is impossible to set cached quarter incomes", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to set cached quarter incomes: %w", err)
+	}
+	return quarterIncomes, nil
+}
+
+// GetRegionAnnualIncomes returns the annual totals published for a region between fromYear and toYear.
+func (a *averageIncome) GetRegionAnnualIncomes(ctx context.Context, regionId int32, fromYear int32, toYear int32) ([]*domain.RegionAnnualIncome, error) {
+	cachedAnnualIncomes, err := a.averageIncomeRedisRepository.GetCachedRegionAnnualIncomes(ctx, regionId, fromYear, toYear)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("it is impossible to get a cached annual incomes: %w", err)
+	}
+	if cachedAnnualIncomes != nil {
+		return cachedAnnualIncomes, nil
+	}
+
+	annualIncomes, err := a.averageIncomeRepository.GetRegionAnnualIncomes(ctx, regionId, fromYear, toYear)
+	if err != nil {
+		a.logger.Error("it is impossible to get an annual incomes", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to get an annual incomes: %w", err)
+	}
+	err = a.averageIncomeRedisRepository.SetCachedRegionAnnualIncomes(ctx, annualIncomes, regionId, fromYear, toYear)
+	if err != nil {
+		a.logger.Error("it is impossible to set cached annual incomes", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to set cached annual incomes: %w", err)
+	}
+	return annualIncomes, nil
+}
+
+// validatePeriod rejects a quarter outside 1-4, a quarter without a year and a year outside
+// the range of loaded data. Zero year and quarter mean "not set" and are always valid.
+func (a *averageIncome) validatePeriod(ctx context.Context, year int32, quarter int32) error {
+	if quarter != 0 && (quarter < 1 || quarter > 4) {
+		return domain.NewFieldError(domain.ErrInvalidPeriod, "quarter", fmt.Sprintf("quarter [%d] must be between 1 and 4", quarter))
+	}
+	if quarter != 0 && year == 0 {
+		return domain.NewFieldError(domain.ErrInvalidPeriod, "quarter", "quarter can only be used together with year")
+	}
+	if year == 0 {
+		return nil
+	}
+
+	yearRange, err := a.getLoadedYearRange(ctx)
+	if err != nil {
+		return err
+	}
+	if !yearRange.Contains(year) {
+		return domain.NewFieldError(domain.ErrInvalidPeriod, "year", fmt.Sprintf("year [%d] is outside the loaded range %d-%d", year, yearRange.MinYear, yearRange.MaxYear))
+	}
+	return nil
+}
+
+func (a *averageIncome) getLoadedYearRange(ctx context.Context) (*domain.YearRange, error) {
+	cachedYearRange, err := a.averageIncomeRedisRepository.GetCachedYearRange(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("it is impossible to get a cached year range: %w", err)
+	}
+	if cachedYearRange != nil {
+		return cachedYearRange, nil
+	}
+
+	yearRange, err := a.averageIncomeRepository.GetLoadedYearRange(ctx)
+	if err != nil {
+		a.logger.Error("it is impossible to get a loaded year range", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to get a loaded year range: %w", err)
+	}
+	err = a.averageIncomeRedisRepository.SetCachedYearRange(ctx, yearRange)
+	if err != nil {
+		a.logger.Error("it is impossible to set cached year range", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to set cached year range: %w", err)
+	}
+	return yearRange, nil
+}
+
+// missingRegionIds returns the requested region ids that are absent from found, without duplicates.
+func missingRegionIds(regionIds []int32, found []*domain.AverageRegionIncomes) []int32 {
+	seen := make(map[int32]bool, len(regionIds))
+	for _, regionIncomes := range found {
+		seen[regionIncomes.RegionId] = true
+	}
+
+	missing := make([]int32, 0, len(regionIds))
+	for _, regionId := range regionIds {
+		if !seen[regionId] {
+			seen[regionId] = true
+			missing = append(missing, regionId)
 		}
-		return regionIncomes, nil
 	}
-	return cachedRegionIncomes, nil
+	return missing
+}
 
+// orderByRegionIds arranges region incomes in the order the regions were requested.
+// Regions without data are skipped and repeated ids are returned once.
+func orderByRegionIds(regionIds []int32, regionIncomes []*domain.AverageRegionIncomes) []*domain.AverageRegionIncomes {
+	byRegionId := make(map[int32]*domain.AverageRegionIncomes, len(regionIncomes))
+	for _, ri := range regionIncomes {
+		byRegionId[ri.RegionId] = ri
+	}
+
+	ordered := make([]*domain.AverageRegionIncomes, 0, len(regionIncomes))
+	for _, regionId := range regionIds {
+		if ri, ok := byRegionId[regionId]; ok {
+			ordered = append(ordered, ri)
+			delete(byRegionId, regionId)
+		}
+	}
+	return ordered
 }
@@ -0,0 +1,241 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/processors/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AverageIncomeTestSuite struct {
+	suite.Suite
+	ctrl            *gomock.Controller
+	processor       *averageIncome
+	repository      *mocks.AverageIncomeDBRepository
+	redisRepository *mocks.AverageIncomeRedisRepository
+	logger          *mocks.AverageIncomeLogger
+	ctx             context.Context
+}
+
+func (s *AverageIncomeTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.repository = mocks.NewAverageIncomeDBRepository(s.ctrl)
+	s.redisRepository = mocks.NewAverageIncomeRedisRepository(s.ctrl)
+	s.logger = mocks.NewAverageIncomeLogger(s.ctrl)
+	s.processor = NewAverageIncome(s.repository, s.redisRepository, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *AverageIncomeTestSuite) TestGetRegionIncomesFetchesOnlyMissingRegions() {
+	cached := []*domain.AverageRegionIncomes{{RegionId: 2, AverageRegionIncomes: decimal.NewFromInt(20)}}
+	fetched := []*domain.AverageRegionIncomes{
+		{RegionId: 1, AverageRegionIncomes: decimal.NewFromInt(10)},
+		{RegionId: 3, AverageRegionIncomes: decimal.NewFromInt(30)},
+	}
+
+	gomock.InOrder(
+		s.redisRepository.
+			EXPECT().
+			GetCachedYearRange(gomock.Any()).
+			Return(&domain.YearRange{MinYear: 2019, MaxYear: 2025}, nil),
+		s.redisRepository.
+			EXPECT().
+			GetCachedRegionIncomes(gomock.Any(), []int32{3, 2, 1, 2}, int32(2024), int32(0), domain.DefaultAveraging()).
+			Return(cached, nil),
+		s.repository.
+			EXPECT().
+			GetRegionIncomes(gomock.Any(), []int32{3, 1}, int32(2024), int32(0), domain.DefaultAveraging()).
+			Return(fetched, nil),
+		s.redisRepository.
+			EXPECT().
+			SetCachedRegionIncomes(gomock.Any(), fetched, []int32{3, 1}, int32(2024), int32(0), domain.DefaultAveraging()).
+			Return(nil),
+	)
+
+	result, err := s.processor.GetRegionIncomes(s.ctx, []int32{3, 2, 1, 2}, 2024, 0, domain.DefaultAveraging())
+	require.NoError(s.T(), err)
+	require.Len(s.T(), result, 3)
+	require.Equal(s.T(), int32(3), result[0].RegionId)
+	require.Equal(s.T(), int32(2), result[1].RegionId)
+	require.Equal(s.T(), int32(1), result[2].RegionId)
+}
+
+func (s *AverageIncomeTestSuite) TestGetRegionIncomesReturnsCachedRegionsWhenMissingOnesHaveNoData() {
+	cached := []*domain.AverageRegionIncomes{
+		{RegionId: 2, AverageRegionIncomes: decimal.NewFromInt(20)},
+		{RegionId: 4, AverageRegionIncomes: decimal.NewFromInt(40)},
+	}
+	dbError := fmt.Errorf("regions not found with region_ids [3 1]: %w", domain.ErrNotFound)
+
+	gomock.InOrder(
+		s.redisRepository.
+			EXPECT().
+			GetCachedYearRange(gomock.Any()).
+			Return(&domain.YearRange{MinYear: 2019, MaxYear: 2025}, nil),
+		s.redisRepository.
+			EXPECT().
+			GetCachedRegionIncomes(gomock.Any(), []int32{4, 3, 2, 1}, int32(2024), int32(0), domain.DefaultAveraging()).
+			Return(cached, nil),
+		s.repository.
+			EXPECT().
+			GetRegionIncomes(gomock.Any(), []int32{3, 1}, int32(2024), int32(0), domain.DefaultAveraging()).
+			Return(nil, dbError),
+		s.logger.
+			EXPECT().
+			Error(gomock.Any(), gomock.Any()),
+	)
+
+	result, err := s.processor.GetRegionIncomes(s.ctx, []int32{4, 3, 2, 1}, 2024, 0, domain.DefaultAveraging())
+	require.NoError(s.T(), err)
+	require.Len(s.T(), result, 2)
+	require.Equal(s.T(), int32(4), result[0].RegionId)
+	require.Equal(s.T(), int32(2), result[1].RegionId)
+}
+
+func (s *AverageIncomeTestSuite) TestGetRegionIncomesAllRegionsCacheMiss() {
+	fetched := []*domain.AverageRegionIncomes{
+		{RegionId: 1, AverageRegionIncomes: decimal.NewFromInt(10)},
+		{RegionId: 2, AverageRegionIncomes: decimal.NewFromInt(20)},
+	}
+
+	gomock.InOrder(
+		s.redisRepository.
+			EXPECT().
+			GetCachedRegionIncomes(gomock.Any(), gomock.Nil(), int32(0), int32(0), domain.DefaultAveraging()).
+			Return(nil, redis.Nil),
+		s.repository.
+			EXPECT().
+			GetRegionIncomes(gomock.Any(), gomock.Nil(), int32(0), int32(0), domain.DefaultAveraging()).
+			Return(fetched, nil),
+		s.redisRepository.
+			EXPECT().
+			SetCachedRegionIncomes(gomock.Any(), fetched, gomock.Nil(), int32(0), int32(0), domain.DefaultAveraging()).
+			Return(nil),
+	)
+
+	result, err := s.processor.GetRegionIncomes(s.ctx, nil, 0, 0, domain.DefaultAveraging())
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), fetched, result)
+}
+
+func (s *AverageIncomeTestSuite) TestGetRegionIncomesInvalidWindow() {
+	averaging := domain.Averaging{Window: 0, Method: domain.AveragingMedian}
+
+	_, err := s.processor.GetRegionIncomes(s.ctx, []int32{1}, 0, 0, averaging)
+	require.Error(s.T(), err)
+}
+
+func (s *AverageIncomeTestSuite) TestGetRegionIncomesQuarterWithoutYear() {
+	_, err := s.processor.GetRegionIncomes(s.ctx, []int32{1}, 0, 2, domain.DefaultAveraging())
+
+	var fieldErr *domain.FieldError
+	require.ErrorAs(s.T(), err, &fieldErr)
+	require.Equal(s.T(), "quarter", fieldErr.Field)
+	require.ErrorIs(s.T(), err, domain.ErrInvalidPeriod)
+}
+
+func (s *AverageIncomeTestSuite) TestGetRegionIncomesCalendarModeRequiresYear() {
+	averaging := domain.DefaultAveraging()
+	averaging.Mode = domain.AveragingCalendar
+
+	_, err := s.processor.GetRegionIncomes(s.ctx, []int32{1}, 0, 0, averaging)
+
+	var fieldErr *domain.FieldError
+	require.ErrorAs(s.T(), err, &fieldErr)
+	require.Equal(s.T(), "year", fieldErr.Field)
+}
+
+func (s *AverageIncomeTestSuite) TestGetRegionIncomesYearOutsideLoadedRange() {
+	yearRange := &domain.YearRange{MinYear: 2019, MaxYear: 2025}
+
+	gomock.InOrder(
+		s.redisRepository.
+			EXPECT().
+			GetCachedYearRange(gomock.Any()).
+			Return(nil, redis.Nil),
+		s.repository.
+			EXPECT().
+			GetLoadedYearRange(gomock.Any()).
+			Return(yearRange, nil),
+		s.redisRepository.
+			EXPECT().
+			SetCachedYearRange(gomock.Any(), yearRange).
+			Return(nil),
+	)
+
+	_, err := s.processor.GetRegionIncomes(s.ctx, []int32{1}, 2030, 0, domain.DefaultAveraging())
+
+	var fieldErr *domain.FieldError
+	require.ErrorAs(s.T(), err, &fieldErr)
+	require.Equal(s.T(), "year", fieldErr.Field)
+}
+
+func (s *AverageIncomeTestSuite) TestGetRegionIncomesKeepsNotFoundWrapped() {
+	dbError := fmt.Errorf("regions not found with region_ids [99]: %w", domain.ErrNotFound)
+
+	gomock.InOrder(
+		s.redisRepository.
+			EXPECT().
+			GetCachedRegionIncomes(gomock.Any(), []int32{99}, int32(0), int32(0), domain.DefaultAveraging()).
+			Return([]*domain.AverageRegionIncomes{}, nil),
+		s.repository.
+			EXPECT().
+			GetRegionIncomes(gomock.Any(), []int32{99}, int32(0), int32(0), domain.DefaultAveraging()).
+			Return(nil, dbError),
+		s.logger.
+			EXPECT().
+			Error(gomock.Any(), gomock.Any()),
+	)
+
+	_, err := s.processor.GetRegionIncomes(s.ctx, []int32{99}, 0, 0, domain.DefaultAveraging())
+	require.ErrorIs(s.T(), err, domain.ErrNotFound)
+}
+
+func (s *AverageIncomeTestSuite) TestGetRegionQuarterIncomesCacheHit() {
+	from := domain.YearQuarter{Year: 2019, Quarter: 1}
+	to := domain.YearQuarter{Year: 2025, Quarter: 2}
+	cached := []*domain.RegionQuarterIncome{{RegionId: 2, Year: 2019, Quarter: 1, Value: decimal.NewNullDecimal(decimal.NewFromInt(10))}}
+
+	s.redisRepository.
+		EXPECT().
+		GetCachedRegionQuarterIncomes(gomock.Any(), int32(2), from, to).
+		Return(cached, nil)
+
+	result, err := s.processor.GetRegionQuarterIncomes(s.ctx, 2, from, to)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), cached, result)
+}
+
+func (s *AverageIncomeTestSuite) TestGetRegionAnnualIncomesCacheMiss() {
+	fetched := []*domain.RegionAnnualIncome{{RegionId: 2, Year: 2024, Value: decimal.NewFromInt(48000)}}
+
+	gomock.InOrder(
+		s.redisRepository.
+			EXPECT().
+			GetCachedRegionAnnualIncomes(gomock.Any(), int32(2), int32(2020), int32(0)).
+			Return(nil, redis.Nil),
+		s.repository.
+			EXPECT().
+			GetRegionAnnualIncomes(gomock.Any(), int32(2), int32(2020), int32(0)).
+			Return(fetched, nil),
+		s.redisRepository.
+			EXPECT().
+			SetCachedRegionAnnualIncomes(gomock.Any(), fetched, int32(2), int32(2020), int32(0)).
+			Return(nil),
+	)
+
+	result, err := s.processor.GetRegionAnnualIncomes(s.ctx, 2, 2020, 0)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), fetched, result)
+}
+
+func TestAverageIncomeTestSuite(t *testing.T) {
+	suite.Run(t, new(AverageIncomeTestSuite))
+}
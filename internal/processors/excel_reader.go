@@ -2,41 +2,192 @@ package processors
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/shopspring/decimal"
 	"log/slog"
+	"time"
 )
 
 // TODO:Tests
 //
 //go:generate mockgen -destination=./mocks/excel_reader_repository.go -package=mocks -mock_names=ExcelReaderRepository=ExcelReaderRepository . ExcelReaderRepository
 type ExcelReaderRepository interface {
-	CreateRegionIncomes(ctx context.Context, exRegionIncomes []*domain.ExcelRegionIncome) error
+	CreateRegionIncomes(ctx context.Context, exRegionIncomes []*domain.ExcelRegionIncome) (*domain.IngestionResult, error)
+	CreateIngestionRejects(ctx context.Context, rejects []*domain.IngestionReject) error
+	GetLastSuccessfulIngestionRun(ctx context.Context, sourceURL string) (*domain.IngestionRun, error)
+	CreateIngestionRun(ctx context.Context, run *domain.IngestionRun) (int64, error)
+	FinishIngestionRun(ctx context.Context, run *domain.IngestionRun) error
+}
+
+//go:generate mockgen -destination=./mocks/excel_file_reader.go -package=mocks -mock_names=ExcelFileReader=ExcelFileReader . ExcelFileReader
+type ExcelFileReader interface {
+	FileSHA256(filePath string) (string, error)
+	ReadFile(filePath string) (*domain.ParsedFile, error)
 }
 
 //go:generate mockgen -destination=./mocks/excel_reader_logger.go -package=mocks -mock_names=ExcelReaderLogger=ExcelReaderLogger . ExcelReaderLogger
 type ExcelReaderLogger interface {
 	Error(msg string, args ...any)
+	Warn(msg string, args ...any)
 	Info(msg string, args ...any)
 }
 
 type excelReader struct {
 	ExcelReaderRepository ExcelReaderRepository
+	FileReader            ExcelFileReader
+	AnnualTolerance       decimal.Decimal
 	Logger                ExcelReaderLogger
 }
 
-func NewExcelReader(repository ExcelReaderRepository, log ExcelReaderLogger) *excelReader {
+func NewExcelReader(repository ExcelReaderRepository, fileReader ExcelFileReader, annualTolerance decimal.Decimal, log ExcelReaderLogger) *excelReader {
 	return &excelReader{
 		ExcelReaderRepository: repository,
+		FileReader:            fileReader,
+		AnnualTolerance:       annualTolerance,
 		Logger:                log,
 	}
 }
 
-func (er *excelReader) CreateRegionIncomes(ctx context.Context, exRegionIncomes []*domain.ExcelRegionIncome) error {
-	err := er.ExcelReaderRepository.CreateRegionIncomes(ctx, exRegionIncomes)
+// LastSuccessfulRun returns the run whose file is currently loaded from the source, or nil
+// before its first successful run. Its validators and hash let the next run skip an unchanged
+// source. Each source has runs of its own, so that the workbook and the SDMX feed are compared
+// with their own previous downloads.
+func (er *excelReader) LastSuccessfulRun(ctx context.Context, sourceURL string) (*domain.IngestionRun, error) {
+	run, err := er.ExcelReaderRepository.GetLastSuccessfulIngestionRun(ctx, sourceURL)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
 	if err != nil {
-		er.Logger.Error("error creating region incomes", slog.String("error", err.Error()))
-		return fmt.Errorf("error creating region incomes: %w", err)
+		er.Logger.Error("error getting last successful ingestion run", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("error getting last successful ingestion run: %w", err)
+	}
+	return run, nil
+}
+
+// IngestSource reads a downloaded source file and loads it, recording the run in the ingestion
+// audit log. The run is recorded as failed, with the counters reached so far, when any step fails.
+// A source the server reported as not modified, or whose contents hash the same as the file of
+// the source's last successful run, is not parsed unless it is forced; the run is recorded as
+// skipped.
+func (er *excelReader) IngestSource(ctx context.Context, source *domain.IngestionSource) (*domain.IngestionRun, error) {
+	run := &domain.IngestionRun{
+		StartedAt:    time.Now(),
+		SourceURL:    source.URL,
+		LocalPath:    source.LocalPath,
+		ETag:         source.ETag,
+		LastModified: source.LastModified,
+		Status:       domain.IngestionRunRunning,
+	}
+	id, err := er.ExcelReaderRepository.CreateIngestionRun(ctx, run)
+	if err != nil {
+		er.Logger.Error("error recording ingestion run", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("error recording ingestion run: %w", err)
+	}
+	run.ID = id
+
+	runErr := er.ingestSource(ctx, run, source)
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Status = domain.IngestionRunSucceeded
+	if run.SkipReason != "" {
+		run.Status = domain.IngestionRunSkipped
+	}
+	if runErr != nil {
+		run.Status = domain.IngestionRunFailed
+		run.Error = runErr.Error()
+	}
+	// The outcome is recorded even when the run was interrupted by a cancelled context.
+	if err := er.ExcelReaderRepository.FinishIngestionRun(context.WithoutCancel(ctx), run); err != nil {
+		er.Logger.Error("error finishing ingestion run", slog.Int64("run_id", run.ID), slog.String("error", err.Error()))
+		return run, errors.Join(runErr, fmt.Errorf("error finishing ingestion run: %w", err))
+	}
+	return run, runErr
+}
+
+func (er *excelReader) ingestSource(ctx context.Context, run *domain.IngestionRun, source *domain.IngestionSource) error {
+	lastRun := source.LastRun
+	if source.NotModified {
+		if lastRun != nil {
+			run.FileSHA256 = lastRun.FileSHA256
+		}
+		run.SkipReason = "source not modified since the last download"
+		return nil
+	}
+
+	fileSHA256, err := er.FileReader.FileSHA256(run.LocalPath)
+	if err != nil {
+		return fmt.Errorf("error fingerprinting file: %w", err)
+	}
+	run.FileSHA256 = fileSHA256
+
+	if lastRun != nil && lastRun.FileSHA256 == fileSHA256 && !source.Force {
+		run.SkipReason = fmt.Sprintf("file contents unchanged since run [%d]", lastRun.ID)
+		return nil
 	}
+
+	parsedFile, err := er.FileReader.ReadFile(run.LocalPath)
+	if err != nil {
+		return fmt.Errorf("error reading file: %w", err)
+	}
+	run.SheetCount = parsedFile.SheetCount
+	run.RowsParsed = parsedFile.RowCount
+	for _, income := range parsedFile.Incomes {
+		income.DownloadedAt = source.DownloadedAt
+	}
+
+	result, err := er.IngestFile(ctx, parsedFile)
+	if err != nil {
+		return err
+	}
+	run.RowsInserted = result.RowsInserted
+	run.AnnualRowsInserted = result.AnnualRowsInserted
+	run.RowsRejected = result.RowsRejected
+	run.UnmatchedRegions = result.UnresolvedRegions
 	return nil
 }
+
+// IngestFile quarantines the rows of a parsed file that failed to parse and loads its values.
+// Published annual totals that disagree with the mean of their quarters are loaded as is and
+// reported as warnings.
+func (er *excelReader) IngestFile(ctx context.Context, parsedFile *domain.ParsedFile) (*domain.IngestionResult, error) {
+	for _, mismatch := range domain.CheckAnnualTotals(parsedFile.Incomes, er.AnnualTolerance) {
+		er.Logger.Warn("annual total differs from the mean of its quarters",
+			slog.String("sheet", mismatch.Sheet),
+			slog.String("region", mismatch.Region),
+			slog.Int("year", int(mismatch.Year)),
+			slog.String("annual", mismatch.Annual.String()),
+			slog.String("quarter_mean", mismatch.QuarterMean.String()),
+			slog.String("deviation_percent", mismatch.DeviationPercent.StringFixed(2)),
+		)
+	}
+
+	err := er.ExcelReaderRepository.CreateIngestionRejects(ctx, parsedFile.Rejects)
+	if err != nil {
+		er.Logger.Error("error quarantining rejected rows", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("error quarantining rejected rows: %w", err)
+	}
+
+	result, err := er.CreateRegionIncomes(ctx, parsedFile.Incomes)
+	if err != nil {
+		return nil, err
+	}
+	result.RowsRejected += len(parsedFile.Rejects)
+	return result, nil
+}
+
+// CreateRegionIncomes loads the parsed rows and reports every source region name that could
+// not be matched to a region or an alias; rows of those regions are skipped.
+func (er *excelReader) CreateRegionIncomes(ctx context.Context, exRegionIncomes []*domain.ExcelRegionIncome) (*domain.IngestionResult, error) {
+	result, err := er.ExcelReaderRepository.CreateRegionIncomes(ctx, exRegionIncomes)
+	if err != nil {
+		er.Logger.Error("error creating region incomes", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("error creating region incomes: %w", err)
+	}
+	for _, regionName := range result.UnresolvedRegions {
+		er.Logger.Warn("region name not resolved, its rows were skipped; add an alias for it", slog.String("region", regionName))
+	}
+	return result, nil
+}
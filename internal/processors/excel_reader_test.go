@@ -7,9 +7,11 @@ import (
 	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
 	"github.com/donskova1ex/AverageRegionIncomes/internal/processors/mocks"
 	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type ExcelReaderTestSuite struct {
@@ -17,6 +19,7 @@ type ExcelReaderTestSuite struct {
 	ctrl       *gomock.Controller
 	processor  *excelReader
 	repository *mocks.ExcelReaderRepository
+	fileReader *mocks.ExcelFileReader
 	logger     *mocks.ExcelReaderLogger
 	ctx        context.Context
 }
@@ -25,7 +28,8 @@ func (s *ExcelReaderTestSuite) SetupSuite() {
 	s.ctrl = gomock.NewController(s.T())
 	s.logger = mocks.NewExcelReaderLogger(s.ctrl)
 	s.repository = mocks.NewExcelReaderRepository(s.ctrl)
-	s.processor = NewExcelReader(s.repository, s.logger)
+	s.fileReader = mocks.NewExcelFileReader(s.ctrl)
+	s.processor = NewExcelReader(s.repository, s.fileReader, decimal.NewFromInt(1), s.logger)
 	s.ctx = context.Background()
 }
 
@@ -38,7 +42,7 @@ func (s *ExcelReaderTestSuite) TestCreateRegionIncomeError() {
 			Region:               "test1",
 			Year:                 1990,
 			Quarter:              1,
-			AverageRegionIncomes: 10,
+			AverageRegionIncomes: decimal.NewFromInt(10),
 		},
 	}
 
@@ -46,15 +50,278 @@ func (s *ExcelReaderTestSuite) TestCreateRegionIncomeError() {
 		s.repository.
 			EXPECT().
 			CreateRegionIncomes(gomock.Any(), gomock.Any()).
-			Return(dbError),
+			Return(nil, dbError),
 		s.logger.
 			EXPECT().
 			Error(gomock.Any(), gomock.Any()),
 	)
-	err := s.processor.CreateRegionIncomes(s.ctx, exRegionIncomes)
+	_, err := s.processor.CreateRegionIncomes(s.ctx, exRegionIncomes)
 	require.EqualError(s.T(), err, expectedError.Error())
 }
 
+func (s *ExcelReaderTestSuite) TestCreateRegionIncomeReportsUnresolvedRegions() {
+	result := &domain.IngestionResult{RowsRead: 3, RowsInserted: 1, UnresolvedRegions: []string{"Кузбасс", "Энская область"}}
+
+	gomock.InOrder(
+		s.repository.
+			EXPECT().
+			CreateRegionIncomes(gomock.Any(), gomock.Any()).
+			Return(result, nil),
+		s.logger.
+			EXPECT().
+			Warn(gomock.Any(), gomock.Any()).
+			Times(2),
+	)
+	actual, err := s.processor.CreateRegionIncomes(s.ctx, nil)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), result, actual)
+}
+
+func (s *ExcelReaderTestSuite) TestIngestFileQuarantinesParseRejects() {
+	row := &domain.SourceRow{File: "file.xlsx", Sheet: "Sheet1", RowNumber: 5, Header: []string{"", "2024.1"}, Cells: []string{"Регион", "abc"}}
+	parsedFile := &domain.ParsedFile{
+		Path:    "file.xlsx",
+		Rejects: []*domain.IngestionReject{{Row: row, Reason: "failed to parse income"}},
+	}
+
+	gomock.InOrder(
+		s.repository.
+			EXPECT().
+			CreateIngestionRejects(gomock.Any(), parsedFile.Rejects).
+			Return(nil),
+		s.repository.
+			EXPECT().
+			CreateRegionIncomes(gomock.Any(), gomock.Nil()).
+			Return(&domain.IngestionResult{}, nil),
+	)
+	result, err := s.processor.IngestFile(s.ctx, parsedFile)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, result.RowsRejected)
+}
+
+func (s *ExcelReaderTestSuite) TestIngestFileWarnsAboutAnnualTotalMismatch() {
+	incomes := []*domain.ExcelRegionIncome{
+		{Sheet: "2024", Region: "Регион", PeriodType: domain.PeriodQuarter, Year: 2024, Quarter: 1, AverageRegionIncomes: decimal.NewFromInt(100)},
+		{Sheet: "2024", Region: "Регион", PeriodType: domain.PeriodQuarter, Year: 2024, Quarter: 2, AverageRegionIncomes: decimal.NewFromInt(100)},
+		{Sheet: "2024", Region: "Регион", PeriodType: domain.PeriodQuarter, Year: 2024, Quarter: 3, AverageRegionIncomes: decimal.NewFromInt(100)},
+		{Sheet: "2024", Region: "Регион", PeriodType: domain.PeriodQuarter, Year: 2024, Quarter: 4, AverageRegionIncomes: decimal.NewFromInt(100)},
+		{Sheet: "2024", Region: "Регион", PeriodType: domain.PeriodYear, Year: 2024, AverageRegionIncomes: decimal.NewFromInt(110)},
+	}
+	parsedFile := &domain.ParsedFile{Path: "file.xlsx", Incomes: incomes}
+
+	gomock.InOrder(
+		s.logger.
+			EXPECT().
+			Warn("annual total differs from the mean of its quarters", gomock.Any()),
+		s.repository.
+			EXPECT().
+			CreateIngestionRejects(gomock.Any(), gomock.Nil()).
+			Return(nil),
+		s.repository.
+			EXPECT().
+			CreateRegionIncomes(gomock.Any(), incomes).
+			Return(&domain.IngestionResult{RowsRead: 5, RowsInserted: 5}, nil),
+	)
+	result, err := s.processor.IngestFile(s.ctx, parsedFile)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(5), result.RowsInserted)
+}
+
+func (s *ExcelReaderTestSuite) TestLastSuccessfulRunIsNilBeforeTheFirstRun() {
+	s.repository.
+		EXPECT().
+		GetLastSuccessfulIngestionRun(gomock.Any(), "https://rosstat.gov.ru/file.xlsx").
+		Return(nil, fmt.Errorf("successful ingestion run not found: %w", domain.ErrNotFound))
+	run, err := s.processor.LastSuccessfulRun(s.ctx, "https://rosstat.gov.ru/file.xlsx")
+	require.NoError(s.T(), err)
+	require.Nil(s.T(), run)
+}
+
+func (s *ExcelReaderTestSuite) TestIngestSourceRecordsRun() {
+	incomes := []*domain.ExcelRegionIncome{{Region: "Республика Башкортостан", PeriodType: domain.PeriodQuarter, Year: 2024, Quarter: 1}}
+	parsedFile := &domain.ParsedFile{Path: "file.xlsx", SheetCount: 2, RowCount: 85, Incomes: incomes}
+	downloadedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	result := &domain.IngestionResult{RowsRead: 340, RowsInserted: 336, AnnualRowsInserted: 84, RowsRejected: 1, UnresolvedRegions: []string{"Кузбасс"}}
+
+	var finished *domain.IngestionRun
+	gomock.InOrder(
+		s.repository.
+			EXPECT().
+			CreateIngestionRun(gomock.Any(), gomock.Any()).
+			Return(int64(7), nil),
+		s.fileReader.
+			EXPECT().
+			FileSHA256("file.xlsx").
+			Return("abc123", nil),
+		s.fileReader.
+			EXPECT().
+			ReadFile("file.xlsx").
+			Return(parsedFile, nil),
+		s.repository.
+			EXPECT().
+			CreateIngestionRejects(gomock.Any(), gomock.Nil()).
+			Return(nil),
+		s.repository.
+			EXPECT().
+			CreateRegionIncomes(gomock.Any(), incomes).
+			DoAndReturn(func(_ context.Context, loaded []*domain.ExcelRegionIncome) (*domain.IngestionResult, error) {
+				require.Equal(s.T(), downloadedAt, loaded[0].DownloadedAt)
+				return result, nil
+			}),
+		s.logger.
+			EXPECT().
+			Warn(gomock.Any(), gomock.Any()),
+		s.repository.
+			EXPECT().
+			FinishIngestionRun(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, run *domain.IngestionRun) error {
+				finished = run
+				return nil
+			}),
+	)
+	run, err := s.processor.IngestSource(s.ctx, &domain.IngestionSource{
+		URL:          "https://rosstat.gov.ru/file.xlsx",
+		LocalPath:    "file.xlsx",
+		DownloadedAt: downloadedAt,
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), run, finished)
+	require.Equal(s.T(), int64(7), run.ID)
+	require.Equal(s.T(), domain.IngestionRunSucceeded, run.Status)
+	require.Equal(s.T(), "abc123", run.FileSHA256)
+	require.Equal(s.T(), 2, run.SheetCount)
+	require.Equal(s.T(), 85, run.RowsParsed)
+	require.Equal(s.T(), int64(336), run.RowsInserted)
+	require.Equal(s.T(), int64(84), run.AnnualRowsInserted)
+	require.Equal(s.T(), 1, run.RowsRejected)
+	require.Equal(s.T(), []string{"Кузбасс"}, run.UnmatchedRegions)
+	require.NotNil(s.T(), run.FinishedAt)
+}
+
+func (s *ExcelReaderTestSuite) TestIngestSourceRecordsFailedRun() {
+	readErr := errors.New("no sheets found in file")
+
+	gomock.InOrder(
+		s.repository.
+			EXPECT().
+			CreateIngestionRun(gomock.Any(), gomock.Any()).
+			Return(int64(8), nil),
+		s.fileReader.
+			EXPECT().
+			FileSHA256("file.xlsx").
+			Return("abc123", nil),
+		s.fileReader.
+			EXPECT().
+			ReadFile("file.xlsx").
+			Return(nil, readErr),
+		s.repository.
+			EXPECT().
+			FinishIngestionRun(gomock.Any(), gomock.Any()).
+			Return(nil),
+	)
+	run, err := s.processor.IngestSource(s.ctx, &domain.IngestionSource{
+		URL:       "https://rosstat.gov.ru/file.xlsx",
+		LocalPath: "file.xlsx",
+		LastRun:   &domain.IngestionRun{ID: 7, FileSHA256: "def456"},
+	})
+	require.ErrorIs(s.T(), err, readErr)
+	require.Equal(s.T(), domain.IngestionRunFailed, run.Status)
+	require.Equal(s.T(), "abc123", run.FileSHA256)
+	require.Contains(s.T(), run.Error, readErr.Error())
+}
+
+func (s *ExcelReaderTestSuite) TestIngestSourceSkipsUnchangedContents() {
+	gomock.InOrder(
+		s.repository.
+			EXPECT().
+			CreateIngestionRun(gomock.Any(), gomock.Any()).
+			Return(int64(9), nil),
+		s.fileReader.
+			EXPECT().
+			FileSHA256("file.xlsx").
+			Return("abc123", nil),
+		s.repository.
+			EXPECT().
+			FinishIngestionRun(gomock.Any(), gomock.Any()).
+			Return(nil),
+	)
+	run, err := s.processor.IngestSource(s.ctx, &domain.IngestionSource{
+		URL:       "https://rosstat.gov.ru/file.xlsx",
+		LocalPath: "file.xlsx",
+		LastRun:   &domain.IngestionRun{ID: 7, FileSHA256: "abc123"},
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domain.IngestionRunSkipped, run.Status)
+	require.Equal(s.T(), "file contents unchanged since run [7]", run.SkipReason)
+}
+
+func (s *ExcelReaderTestSuite) TestIngestSourceForcesUnchangedContents() {
+	parsedFile := &domain.ParsedFile{Path: "file.xlsx", SheetCount: 1, RowCount: 85}
+
+	gomock.InOrder(
+		s.repository.
+			EXPECT().
+			CreateIngestionRun(gomock.Any(), gomock.Any()).
+			Return(int64(11), nil),
+		s.fileReader.
+			EXPECT().
+			FileSHA256("file.xlsx").
+			Return("abc123", nil),
+		s.fileReader.
+			EXPECT().
+			ReadFile("file.xlsx").
+			Return(parsedFile, nil),
+		s.repository.
+			EXPECT().
+			CreateIngestionRejects(gomock.Any(), gomock.Nil()).
+			Return(nil),
+		s.repository.
+			EXPECT().
+			CreateRegionIncomes(gomock.Any(), gomock.Nil()).
+			Return(&domain.IngestionResult{}, nil),
+		s.repository.
+			EXPECT().
+			FinishIngestionRun(gomock.Any(), gomock.Any()).
+			Return(nil),
+	)
+	run, err := s.processor.IngestSource(s.ctx, &domain.IngestionSource{
+		URL:       "https://rosstat.gov.ru/file.xlsx",
+		LocalPath: "file.xlsx",
+		Force:     true,
+		LastRun:   &domain.IngestionRun{ID: 7, FileSHA256: "abc123"},
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domain.IngestionRunSucceeded, run.Status)
+	require.Empty(s.T(), run.SkipReason)
+	require.Equal(s.T(), 85, run.RowsParsed)
+}
+
+func (s *ExcelReaderTestSuite) TestIngestSourceSkipsNotModifiedSource() {
+	source := &domain.IngestionSource{
+		URL:         "https://rosstat.gov.ru/file.xlsx",
+		LocalPath:   "old_file.xlsx",
+		ETag:        `"5f3a-1"`,
+		NotModified: true,
+		LastRun:     &domain.IngestionRun{ID: 9, FileSHA256: "abc123"},
+	}
+
+	gomock.InOrder(
+		s.repository.
+			EXPECT().
+			CreateIngestionRun(gomock.Any(), gomock.Any()).
+			Return(int64(10), nil),
+		s.repository.
+			EXPECT().
+			FinishIngestionRun(gomock.Any(), gomock.Any()).
+			Return(nil),
+	)
+	run, err := s.processor.IngestSource(s.ctx, source)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), domain.IngestionRunSkipped, run.Status)
+	require.Equal(s.T(), "abc123", run.FileSHA256)
+	require.Equal(s.T(), `"5f3a-1"`, run.ETag)
+}
+
 func TestExcelReaderTestSuite(t *testing.T) {
 	suite.Run(t, new(ExcelReaderTestSuite))
 }
@@ -0,0 +1,106 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"log/slog"
+	"slices"
+)
+
+//go:generate mockgen -destination=./mocks/ingestion_rejects_repository.go -package=mocks -mock_names=IngestionRejectsRepository=IngestionRejectsRepository . IngestionRejectsRepository
+type IngestionRejectsRepository interface {
+	GetIngestionRejects(ctx context.Context, status domain.IngestionRejectStatus, limit int32) ([]*domain.IngestionReject, error)
+	CreateIngestionRejects(ctx context.Context, rejects []*domain.IngestionReject) error
+	CreateRegionIncomes(ctx context.Context, exRegionIncomes []*domain.ExcelRegionIncome) (*domain.IngestionResult, error)
+}
+
+//go:generate mockgen -destination=./mocks/row_parser.go -package=mocks -mock_names=RowParser=RowParser . RowParser
+type RowParser interface {
+	ParseRow(row *domain.SourceRow) ([]*domain.ExcelRegionIncome, error)
+}
+
+//go:generate mockgen -destination=./mocks/ingestion_rejects_logger.go -package=mocks -mock_names=IngestionRejectsLogger=IngestionRejectsLogger . IngestionRejectsLogger
+type IngestionRejectsLogger interface {
+	Error(msg string, args ...any)
+	Info(msg string, args ...any)
+}
+
+type ingestionRejects struct {
+	ingestionRejectsRepository IngestionRejectsRepository
+	rowParser                  RowParser
+	logger                     IngestionRejectsLogger
+}
+
+func NewIngestionRejects(ingestionRejectsRepository IngestionRejectsRepository, rowParser RowParser, log IngestionRejectsLogger) *ingestionRejects {
+	return &ingestionRejects{ingestionRejectsRepository, rowParser, log}
+}
+
+func (ir *ingestionRejects) GetIngestionRejects(ctx context.Context, status domain.IngestionRejectStatus, limit int32) ([]*domain.IngestionReject, error) {
+	rejects, err := ir.ingestionRejectsRepository.GetIngestionRejects(ctx, status, limit)
+	if err != nil {
+		ir.logger.Error("it is impossible to get ingestion rejects", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to get ingestion rejects: %w", err)
+	}
+	return rejects, nil
+}
+
+// ReprocessRejects parses the open rejects again, all of them or only those with the given ids,
+// and loads the rows that now parse and resolve to a region. Loaded rows are marked resolved by
+// the repository; the others stay in quarantine with an updated reason. Given ids that are not
+// open rejects are returned as not open.
+func (ir *ingestionRejects) ReprocessRejects(ctx context.Context, ids []int64) (*domain.ReprocessResult, error) {
+	openRejects, err := ir.GetIngestionRejects(ctx, domain.IngestionRejectOpen, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ReprocessResult{}
+	var incomes []*domain.ExcelRegionIncome
+	var stillRejected []*domain.IngestionReject
+	for _, reject := range openRejects {
+		if len(ids) > 0 && !slices.Contains(ids, reject.ID) {
+			continue
+		}
+		result.Reprocessed++
+
+		rowIncomes, err := ir.rowParser.ParseRow(reject.Row)
+		if err != nil {
+			stillRejected = append(stillRejected, &domain.IngestionReject{Row: reject.Row, Reason: err.Error()})
+			continue
+		}
+		incomes = append(incomes, rowIncomes...)
+	}
+
+	if len(stillRejected) > 0 {
+		if err := ir.ingestionRejectsRepository.CreateIngestionRejects(ctx, stillRejected); err != nil {
+			ir.logger.Error("it is impossible to update ingestion rejects", slog.String("err", err.Error()))
+			return nil, fmt.Errorf("it is impossible to update ingestion rejects: %w", err)
+		}
+	}
+	result.Rejected = len(stillRejected)
+
+	if len(incomes) > 0 {
+		ingestionResult, err := ir.ingestionRejectsRepository.CreateRegionIncomes(ctx, incomes)
+		if err != nil {
+			ir.logger.Error("it is impossible to load reprocessed rows", slog.String("err", err.Error()))
+			return nil, fmt.Errorf("it is impossible to load reprocessed rows: %w", err)
+		}
+		result.Rejected += ingestionResult.RowsRejected
+	}
+	result.Resolved = result.Reprocessed - result.Rejected
+
+	for _, id := range ids {
+		isOpen := slices.ContainsFunc(openRejects, func(reject *domain.IngestionReject) bool { return reject.ID == id })
+		if !isOpen && !slices.Contains(result.NotOpen, id) {
+			result.NotOpen = append(result.NotOpen, id)
+		}
+	}
+
+	ir.logger.Info("ingestion rejects reprocessed",
+		slog.Int("reprocessed", result.Reprocessed),
+		slog.Int("resolved", result.Resolved),
+		slog.Int("rejected", result.Rejected),
+		slog.Any("not_open", result.NotOpen))
+	return result, nil
+}
@@ -0,0 +1,124 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/processors/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type IngestionRejectsTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	processor  *ingestionRejects
+	repository *mocks.IngestionRejectsRepository
+	rowParser  *mocks.RowParser
+	logger     *mocks.IngestionRejectsLogger
+	ctx        context.Context
+}
+
+func (s *IngestionRejectsTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.repository = mocks.NewIngestionRejectsRepository(s.ctrl)
+	s.rowParser = mocks.NewRowParser(s.ctrl)
+	s.logger = mocks.NewIngestionRejectsLogger(s.ctrl)
+	s.processor = NewIngestionRejects(s.repository, s.rowParser, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *IngestionRejectsTestSuite) TestReprocessRejectsSelectedIds() {
+	header := []string{"", "2024.1"}
+	fixedRow := &domain.SourceRow{File: "file.xlsx", Sheet: "Sheet1", RowNumber: 5, Header: header, Cells: []string{"Кузбасс", "100"}}
+	brokenRow := &domain.SourceRow{File: "file.xlsx", Sheet: "Sheet1", RowNumber: 6, Header: header, Cells: []string{"Энская область", "abc"}}
+	skippedRow := &domain.SourceRow{File: "file.xlsx", Sheet: "Sheet1", RowNumber: 7, Header: header, Cells: []string{"Другая область", "1"}}
+	openRejects := []*domain.IngestionReject{
+		{ID: 1, Row: fixedRow, Reason: "region [Кузбасс] does not match any region name or alias"},
+		{ID: 2, Row: brokenRow, Reason: "failed to parse income"},
+		{ID: 3, Row: skippedRow, Reason: "failed to parse income"},
+	}
+	incomes := []*domain.ExcelRegionIncome{{Region: "Кузбасс", Year: 2024, Quarter: 1, AverageRegionIncomes: decimal.NewFromInt(100), Source: fixedRow}}
+	parseErr := errors.New("failed to parse income: can't convert abc to decimal")
+
+	gomock.InOrder(
+		s.repository.
+			EXPECT().
+			GetIngestionRejects(gomock.Any(), domain.IngestionRejectOpen, int32(0)).
+			Return(openRejects, nil),
+		s.rowParser.
+			EXPECT().
+			ParseRow(fixedRow).
+			Return(incomes, nil),
+		s.rowParser.
+			EXPECT().
+			ParseRow(brokenRow).
+			Return(nil, parseErr),
+		s.repository.
+			EXPECT().
+			CreateIngestionRejects(gomock.Any(), []*domain.IngestionReject{{Row: brokenRow, Reason: parseErr.Error()}}).
+			Return(nil),
+		s.repository.
+			EXPECT().
+			CreateRegionIncomes(gomock.Any(), incomes).
+			Return(&domain.IngestionResult{RowsRead: 1, RowsInserted: 1}, nil),
+		s.logger.
+			EXPECT().
+			Info(gomock.Any(), gomock.Any()),
+	)
+
+	result, err := s.processor.ReprocessRejects(s.ctx, []int64{1, 2})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), &domain.ReprocessResult{Reprocessed: 2, Resolved: 1, Rejected: 1}, result)
+}
+
+func (s *IngestionRejectsTestSuite) TestReprocessRejectsReportsIdsNotOpen() {
+	row := &domain.SourceRow{File: "file.xlsx", Sheet: "Sheet1", RowNumber: 5, Header: []string{"", "2024.1"}, Cells: []string{"Кузбасс", "100"}}
+	incomes := []*domain.ExcelRegionIncome{{Region: "Кузбасс", Year: 2024, Quarter: 1, AverageRegionIncomes: decimal.NewFromInt(100), Source: row}}
+
+	gomock.InOrder(
+		s.repository.
+			EXPECT().
+			GetIngestionRejects(gomock.Any(), domain.IngestionRejectOpen, int32(0)).
+			Return([]*domain.IngestionReject{{ID: 1, Row: row, Reason: "region [Кузбасс] does not match any region name or alias"}}, nil),
+		s.rowParser.
+			EXPECT().
+			ParseRow(row).
+			Return(incomes, nil),
+		s.repository.
+			EXPECT().
+			CreateRegionIncomes(gomock.Any(), incomes).
+			Return(&domain.IngestionResult{RowsRead: 1, RowsInserted: 1}, nil),
+		s.logger.
+			EXPECT().
+			Info(gomock.Any(), gomock.Any()),
+	)
+
+	result, err := s.processor.ReprocessRejects(s.ctx, []int64{4, 1, 9, 4})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), &domain.ReprocessResult{Reprocessed: 1, Resolved: 1, NotOpen: []int64{4, 9}}, result)
+}
+
+func (s *IngestionRejectsTestSuite) TestReprocessRejectsWithNoOpenIds() {
+	gomock.InOrder(
+		s.repository.
+			EXPECT().
+			GetIngestionRejects(gomock.Any(), domain.IngestionRejectOpen, int32(0)).
+			Return([]*domain.IngestionReject{{ID: 1, Row: &domain.SourceRow{}}}, nil),
+		s.logger.
+			EXPECT().
+			Info(gomock.Any(), gomock.Any()),
+	)
+
+	result, err := s.processor.ReprocessRejects(s.ctx, []int64{7})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), &domain.ReprocessResult{NotOpen: []int64{7}}, result)
+}
+
+func TestIngestionRejectsTestSuite(t *testing.T) {
+	suite.Run(t, new(IngestionRejectsTestSuite))
+}
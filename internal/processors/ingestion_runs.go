@@ -0,0 +1,38 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"log/slog"
+)
+
+//go:generate mockgen -destination=./mocks/ingestion_runs_repository.go -package=mocks -mock_names=IngestionRunsRepository=IngestionRunsRepository . IngestionRunsRepository
+type IngestionRunsRepository interface {
+	GetIngestionRuns(ctx context.Context, limit int32) ([]*domain.IngestionRun, error)
+}
+
+//go:generate mockgen -destination=./mocks/ingestion_runs_logger.go -package=mocks -mock_names=IngestionRunsLogger=IngestionRunsLogger . IngestionRunsLogger
+type IngestionRunsLogger interface {
+	Error(msg string, args ...any)
+	Info(msg string, args ...any)
+}
+
+type ingestionRuns struct {
+	ingestionRunsRepository IngestionRunsRepository
+	logger                  IngestionRunsLogger
+}
+
+func NewIngestionRuns(ingestionRunsRepository IngestionRunsRepository, log IngestionRunsLogger) *ingestionRuns {
+	return &ingestionRuns{ingestionRunsRepository, log}
+}
+
+// GetIngestionRuns returns the most recent ingestion runs, newest first.
+func (ir *ingestionRuns) GetIngestionRuns(ctx context.Context, limit int32) ([]*domain.IngestionRun, error) {
+	runs, err := ir.ingestionRunsRepository.GetIngestionRuns(ctx, limit)
+	if err != nil {
+		ir.logger.Error("it is impossible to get ingestion runs", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to get ingestion runs: %w", err)
+	}
+	return runs, nil
+}
@@ -35,17 +35,62 @@ func (m *AverageIncomeDBRepository) EXPECT() *AverageIncomeDBRepositoryMockRecor
 	return m.recorder
 }
 
+// GetLoadedYearRange mocks base method.
+func (m *AverageIncomeDBRepository) GetLoadedYearRange(arg0 context.Context) (*domain.YearRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadedYearRange", arg0)
+	ret0, _ := ret[0].(*domain.YearRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadedYearRange indicates an expected call of GetLoadedYearRange.
+func (mr *AverageIncomeDBRepositoryMockRecorder) GetLoadedYearRange(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadedYearRange", reflect.TypeOf((*AverageIncomeDBRepository)(nil).GetLoadedYearRange), arg0)
+}
+
+// GetRegionAnnualIncomes mocks base method.
+func (m *AverageIncomeDBRepository) GetRegionAnnualIncomes(arg0 context.Context, arg1, arg2, arg3 int32) ([]*domain.RegionAnnualIncome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegionAnnualIncomes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.RegionAnnualIncome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegionAnnualIncomes indicates an expected call of GetRegionAnnualIncomes.
+func (mr *AverageIncomeDBRepositoryMockRecorder) GetRegionAnnualIncomes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegionAnnualIncomes", reflect.TypeOf((*AverageIncomeDBRepository)(nil).GetRegionAnnualIncomes), arg0, arg1, arg2, arg3)
+}
+
 // GetRegionIncomes mocks base method.
-func (m *AverageIncomeDBRepository) GetRegionIncomes(arg0 context.Context, arg1, arg2, arg3 int32) (*domain.AverageRegionIncomes, error) {
+func (m *AverageIncomeDBRepository) GetRegionIncomes(arg0 context.Context, arg1 []int32, arg2, arg3 int32, arg4 domain.Averaging) ([]*domain.AverageRegionIncomes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRegionIncomes", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(*domain.AverageRegionIncomes)
+	ret := m.ctrl.Call(m, "GetRegionIncomes", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*domain.AverageRegionIncomes)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRegionIncomes indicates an expected call of GetRegionIncomes.
-func (mr *AverageIncomeDBRepositoryMockRecorder) GetRegionIncomes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *AverageIncomeDBRepositoryMockRecorder) GetRegionIncomes(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegionIncomes", reflect.TypeOf((*AverageIncomeDBRepository)(nil).GetRegionIncomes), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetRegionQuarterIncomes mocks base method.
+func (m *AverageIncomeDBRepository) GetRegionQuarterIncomes(arg0 context.Context, arg1 int32, arg2, arg3 domain.YearQuarter) ([]*domain.RegionQuarterIncome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegionQuarterIncomes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.RegionQuarterIncome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegionQuarterIncomes indicates an expected call of GetRegionQuarterIncomes.
+func (mr *AverageIncomeDBRepositoryMockRecorder) GetRegionQuarterIncomes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegionIncomes", reflect.TypeOf((*AverageIncomeDBRepository)(nil).GetRegionIncomes), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegionQuarterIncomes", reflect.TypeOf((*AverageIncomeDBRepository)(nil).GetRegionQuarterIncomes), arg0, arg1, arg2, arg3)
 }
@@ -35,31 +35,118 @@ func (m *AverageIncomeRedisRepository) EXPECT() *AverageIncomeRedisRepositoryMoc
 	return m.recorder
 }
 
+// GetCachedRegionAnnualIncomes mocks base method.
+func (m *AverageIncomeRedisRepository) GetCachedRegionAnnualIncomes(arg0 context.Context, arg1, arg2, arg3 int32) ([]*domain.RegionAnnualIncome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedRegionAnnualIncomes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.RegionAnnualIncome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedRegionAnnualIncomes indicates an expected call of GetCachedRegionAnnualIncomes.
+func (mr *AverageIncomeRedisRepositoryMockRecorder) GetCachedRegionAnnualIncomes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedRegionAnnualIncomes", reflect.TypeOf((*AverageIncomeRedisRepository)(nil).GetCachedRegionAnnualIncomes), arg0, arg1, arg2, arg3)
+}
+
 // GetCachedRegionIncomes mocks base method.
-func (m *AverageIncomeRedisRepository) GetCachedRegionIncomes(arg0 context.Context, arg1, arg2, arg3 int32) (*domain.AverageRegionIncomes, error) {
+func (m *AverageIncomeRedisRepository) GetCachedRegionIncomes(arg0 context.Context, arg1 []int32, arg2, arg3 int32, arg4 domain.Averaging) ([]*domain.AverageRegionIncomes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCachedRegionIncomes", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(*domain.AverageRegionIncomes)
+	ret := m.ctrl.Call(m, "GetCachedRegionIncomes", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*domain.AverageRegionIncomes)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCachedRegionIncomes indicates an expected call of GetCachedRegionIncomes.
-func (mr *AverageIncomeRedisRepositoryMockRecorder) GetCachedRegionIncomes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *AverageIncomeRedisRepositoryMockRecorder) GetCachedRegionIncomes(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedRegionIncomes", reflect.TypeOf((*AverageIncomeRedisRepository)(nil).GetCachedRegionIncomes), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetCachedRegionQuarterIncomes mocks base method.
+func (m *AverageIncomeRedisRepository) GetCachedRegionQuarterIncomes(arg0 context.Context, arg1 int32, arg2, arg3 domain.YearQuarter) ([]*domain.RegionQuarterIncome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedRegionQuarterIncomes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.RegionQuarterIncome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedRegionQuarterIncomes indicates an expected call of GetCachedRegionQuarterIncomes.
+func (mr *AverageIncomeRedisRepositoryMockRecorder) GetCachedRegionQuarterIncomes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedRegionQuarterIncomes", reflect.TypeOf((*AverageIncomeRedisRepository)(nil).GetCachedRegionQuarterIncomes), arg0, arg1, arg2, arg3)
+}
+
+// GetCachedYearRange mocks base method.
+func (m *AverageIncomeRedisRepository) GetCachedYearRange(arg0 context.Context) (*domain.YearRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedYearRange", arg0)
+	ret0, _ := ret[0].(*domain.YearRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedYearRange indicates an expected call of GetCachedYearRange.
+func (mr *AverageIncomeRedisRepositoryMockRecorder) GetCachedYearRange(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedRegionIncomes", reflect.TypeOf((*AverageIncomeRedisRepository)(nil).GetCachedRegionIncomes), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedYearRange", reflect.TypeOf((*AverageIncomeRedisRepository)(nil).GetCachedYearRange), arg0)
+}
+
+// SetCachedRegionAnnualIncomes mocks base method.
+func (m *AverageIncomeRedisRepository) SetCachedRegionAnnualIncomes(arg0 context.Context, arg1 []*domain.RegionAnnualIncome, arg2, arg3, arg4 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCachedRegionAnnualIncomes", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCachedRegionAnnualIncomes indicates an expected call of SetCachedRegionAnnualIncomes.
+func (mr *AverageIncomeRedisRepositoryMockRecorder) SetCachedRegionAnnualIncomes(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCachedRegionAnnualIncomes", reflect.TypeOf((*AverageIncomeRedisRepository)(nil).SetCachedRegionAnnualIncomes), arg0, arg1, arg2, arg3, arg4)
 }
 
 // SetCachedRegionIncomes mocks base method.
-func (m *AverageIncomeRedisRepository) SetCachedRegionIncomes(arg0 context.Context, arg1 *domain.AverageRegionIncomes, arg2, arg3, arg4 int32) error {
+func (m *AverageIncomeRedisRepository) SetCachedRegionIncomes(arg0 context.Context, arg1 []*domain.AverageRegionIncomes, arg2 []int32, arg3, arg4 int32, arg5 domain.Averaging) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetCachedRegionIncomes", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "SetCachedRegionIncomes", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetCachedRegionIncomes indicates an expected call of SetCachedRegionIncomes.
-func (mr *AverageIncomeRedisRepositoryMockRecorder) SetCachedRegionIncomes(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *AverageIncomeRedisRepositoryMockRecorder) SetCachedRegionIncomes(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCachedRegionIncomes", reflect.TypeOf((*AverageIncomeRedisRepository)(nil).SetCachedRegionIncomes), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// SetCachedRegionQuarterIncomes mocks base method.
+func (m *AverageIncomeRedisRepository) SetCachedRegionQuarterIncomes(arg0 context.Context, arg1 []*domain.RegionQuarterIncome, arg2 int32, arg3, arg4 domain.YearQuarter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCachedRegionQuarterIncomes", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCachedRegionQuarterIncomes indicates an expected call of SetCachedRegionQuarterIncomes.
+func (mr *AverageIncomeRedisRepositoryMockRecorder) SetCachedRegionQuarterIncomes(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCachedRegionQuarterIncomes", reflect.TypeOf((*AverageIncomeRedisRepository)(nil).SetCachedRegionQuarterIncomes), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SetCachedYearRange mocks base method.
+func (m *AverageIncomeRedisRepository) SetCachedYearRange(arg0 context.Context, arg1 *domain.YearRange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCachedYearRange", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCachedYearRange indicates an expected call of SetCachedYearRange.
+func (mr *AverageIncomeRedisRepositoryMockRecorder) SetCachedYearRange(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCachedRegionIncomes", reflect.TypeOf((*AverageIncomeRedisRepository)(nil).SetCachedRegionIncomes), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCachedYearRange", reflect.TypeOf((*AverageIncomeRedisRepository)(nil).SetCachedYearRange), arg0, arg1)
 }
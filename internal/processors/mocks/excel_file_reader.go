@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/donskova1ex/AverageRegionIncomes/internal/processors (interfaces: ExcelFileReader)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// ExcelFileReader is a mock of ExcelFileReader interface.
+type ExcelFileReader struct {
+	ctrl     *gomock.Controller
+	recorder *ExcelFileReaderMockRecorder
+}
+
+// ExcelFileReaderMockRecorder is the mock recorder for ExcelFileReader.
+type ExcelFileReaderMockRecorder struct {
+	mock *ExcelFileReader
+}
+
+// NewExcelFileReader creates a new mock instance.
+func NewExcelFileReader(ctrl *gomock.Controller) *ExcelFileReader {
+	mock := &ExcelFileReader{ctrl: ctrl}
+	mock.recorder = &ExcelFileReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *ExcelFileReader) EXPECT() *ExcelFileReaderMockRecorder {
+	return m.recorder
+}
+
+// FileSHA256 mocks base method.
+func (m *ExcelFileReader) FileSHA256(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileSHA256", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FileSHA256 indicates an expected call of FileSHA256.
+func (mr *ExcelFileReaderMockRecorder) FileSHA256(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileSHA256", reflect.TypeOf((*ExcelFileReader)(nil).FileSHA256), arg0)
+}
+
+// ReadFile mocks base method.
+func (m *ExcelFileReader) ReadFile(arg0 string) (*domain.ParsedFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadFile", arg0)
+	ret0, _ := ret[0].(*domain.ParsedFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadFile indicates an expected call of ReadFile.
+func (mr *ExcelFileReaderMockRecorder) ReadFile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFile", reflect.TypeOf((*ExcelFileReader)(nil).ReadFile), arg0)
+}
@@ -35,16 +35,75 @@ func (m *ExcelReaderRepository) EXPECT() *ExcelReaderRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CreateRegionIncomes mocks base method.
-func (m *ExcelReaderRepository) CreateRegionIncomes(arg0 context.Context, arg1 []*domain.ExcelRegionIncome) error {
+// CreateIngestionRejects mocks base method.
+func (m *ExcelReaderRepository) CreateIngestionRejects(arg0 context.Context, arg1 []*domain.IngestionReject) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRegionIncomes", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateIngestionRejects", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// CreateIngestionRejects indicates an expected call of CreateIngestionRejects.
+func (mr *ExcelReaderRepositoryMockRecorder) CreateIngestionRejects(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngestionRejects", reflect.TypeOf((*ExcelReaderRepository)(nil).CreateIngestionRejects), arg0, arg1)
+}
+
+// CreateIngestionRun mocks base method.
+func (m *ExcelReaderRepository) CreateIngestionRun(arg0 context.Context, arg1 *domain.IngestionRun) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngestionRun", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngestionRun indicates an expected call of CreateIngestionRun.
+func (mr *ExcelReaderRepositoryMockRecorder) CreateIngestionRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngestionRun", reflect.TypeOf((*ExcelReaderRepository)(nil).CreateIngestionRun), arg0, arg1)
+}
+
+// CreateRegionIncomes mocks base method.
+func (m *ExcelReaderRepository) CreateRegionIncomes(arg0 context.Context, arg1 []*domain.ExcelRegionIncome) (*domain.IngestionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRegionIncomes", arg0, arg1)
+	ret0, _ := ret[0].(*domain.IngestionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // CreateRegionIncomes indicates an expected call of CreateRegionIncomes.
 func (mr *ExcelReaderRepositoryMockRecorder) CreateRegionIncomes(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRegionIncomes", reflect.TypeOf((*ExcelReaderRepository)(nil).CreateRegionIncomes), arg0, arg1)
 }
+
+// FinishIngestionRun mocks base method.
+func (m *ExcelReaderRepository) FinishIngestionRun(arg0 context.Context, arg1 *domain.IngestionRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishIngestionRun", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishIngestionRun indicates an expected call of FinishIngestionRun.
+func (mr *ExcelReaderRepositoryMockRecorder) FinishIngestionRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishIngestionRun", reflect.TypeOf((*ExcelReaderRepository)(nil).FinishIngestionRun), arg0, arg1)
+}
+
+// GetLastSuccessfulIngestionRun mocks base method.
+func (m *ExcelReaderRepository) GetLastSuccessfulIngestionRun(arg0 context.Context, arg1 string) (*domain.IngestionRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastSuccessfulIngestionRun", arg0, arg1)
+	ret0, _ := ret[0].(*domain.IngestionRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastSuccessfulIngestionRun indicates an expected call of GetLastSuccessfulIngestionRun.
+func (mr *ExcelReaderRepositoryMockRecorder) GetLastSuccessfulIngestionRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastSuccessfulIngestionRun", reflect.TypeOf((*ExcelReaderRepository)(nil).GetLastSuccessfulIngestionRun), arg0, arg1)
+}
@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/donskova1ex/AverageRegionIncomes/internal/processors (interfaces: IngestionRejectsLogger)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// IngestionRejectsLogger is a mock of IngestionRejectsLogger interface.
+type IngestionRejectsLogger struct {
+	ctrl     *gomock.Controller
+	recorder *IngestionRejectsLoggerMockRecorder
+}
+
+// IngestionRejectsLoggerMockRecorder is the mock recorder for IngestionRejectsLogger.
+type IngestionRejectsLoggerMockRecorder struct {
+	mock *IngestionRejectsLogger
+}
+
+// NewIngestionRejectsLogger creates a new mock instance.
+func NewIngestionRejectsLogger(ctrl *gomock.Controller) *IngestionRejectsLogger {
+	mock := &IngestionRejectsLogger{ctrl: ctrl}
+	mock.recorder = &IngestionRejectsLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *IngestionRejectsLogger) EXPECT() *IngestionRejectsLoggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *IngestionRejectsLogger) Error(arg0 string, arg1 ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *IngestionRejectsLoggerMockRecorder) Error(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*IngestionRejectsLogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *IngestionRejectsLogger) Info(arg0 string, arg1 ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *IngestionRejectsLoggerMockRecorder) Info(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*IngestionRejectsLogger)(nil).Info), varargs...)
+}
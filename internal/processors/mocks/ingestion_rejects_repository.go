@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/donskova1ex/AverageRegionIncomes/internal/processors (interfaces: IngestionRejectsRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// IngestionRejectsRepository is a mock of IngestionRejectsRepository interface.
+type IngestionRejectsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *IngestionRejectsRepositoryMockRecorder
+}
+
+// IngestionRejectsRepositoryMockRecorder is the mock recorder for IngestionRejectsRepository.
+type IngestionRejectsRepositoryMockRecorder struct {
+	mock *IngestionRejectsRepository
+}
+
+// NewIngestionRejectsRepository creates a new mock instance.
+func NewIngestionRejectsRepository(ctrl *gomock.Controller) *IngestionRejectsRepository {
+	mock := &IngestionRejectsRepository{ctrl: ctrl}
+	mock.recorder = &IngestionRejectsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *IngestionRejectsRepository) EXPECT() *IngestionRejectsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateIngestionRejects mocks base method.
+func (m *IngestionRejectsRepository) CreateIngestionRejects(arg0 context.Context, arg1 []*domain.IngestionReject) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngestionRejects", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIngestionRejects indicates an expected call of CreateIngestionRejects.
+func (mr *IngestionRejectsRepositoryMockRecorder) CreateIngestionRejects(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngestionRejects", reflect.TypeOf((*IngestionRejectsRepository)(nil).CreateIngestionRejects), arg0, arg1)
+}
+
+// CreateRegionIncomes mocks base method.
+func (m *IngestionRejectsRepository) CreateRegionIncomes(arg0 context.Context, arg1 []*domain.ExcelRegionIncome) (*domain.IngestionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRegionIncomes", arg0, arg1)
+	ret0, _ := ret[0].(*domain.IngestionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRegionIncomes indicates an expected call of CreateRegionIncomes.
+func (mr *IngestionRejectsRepositoryMockRecorder) CreateRegionIncomes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRegionIncomes", reflect.TypeOf((*IngestionRejectsRepository)(nil).CreateRegionIncomes), arg0, arg1)
+}
+
+// GetIngestionRejects mocks base method.
+func (m *IngestionRejectsRepository) GetIngestionRejects(arg0 context.Context, arg1 domain.IngestionRejectStatus, arg2 int32) ([]*domain.IngestionReject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngestionRejects", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.IngestionReject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngestionRejects indicates an expected call of GetIngestionRejects.
+func (mr *IngestionRejectsRepositoryMockRecorder) GetIngestionRejects(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngestionRejects", reflect.TypeOf((*IngestionRejectsRepository)(nil).GetIngestionRejects), arg0, arg1, arg2)
+}
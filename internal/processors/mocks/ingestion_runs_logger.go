@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/donskova1ex/AverageRegionIncomes/internal/processors (interfaces: IngestionRunsLogger)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// IngestionRunsLogger is a mock of IngestionRunsLogger interface.
+type IngestionRunsLogger struct {
+	ctrl     *gomock.Controller
+	recorder *IngestionRunsLoggerMockRecorder
+}
+
+// IngestionRunsLoggerMockRecorder is the mock recorder for IngestionRunsLogger.
+type IngestionRunsLoggerMockRecorder struct {
+	mock *IngestionRunsLogger
+}
+
+// NewIngestionRunsLogger creates a new mock instance.
+func NewIngestionRunsLogger(ctrl *gomock.Controller) *IngestionRunsLogger {
+	mock := &IngestionRunsLogger{ctrl: ctrl}
+	mock.recorder = &IngestionRunsLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *IngestionRunsLogger) EXPECT() *IngestionRunsLoggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *IngestionRunsLogger) Error(arg0 string, arg1 ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *IngestionRunsLoggerMockRecorder) Error(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*IngestionRunsLogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *IngestionRunsLogger) Info(arg0 string, arg1 ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *IngestionRunsLoggerMockRecorder) Info(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*IngestionRunsLogger)(nil).Info), varargs...)
+}
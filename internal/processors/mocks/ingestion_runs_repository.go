@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/donskova1ex/AverageRegionIncomes/internal/processors (interfaces: IngestionRunsRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// IngestionRunsRepository is a mock of IngestionRunsRepository interface.
+type IngestionRunsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *IngestionRunsRepositoryMockRecorder
+}
+
+// IngestionRunsRepositoryMockRecorder is the mock recorder for IngestionRunsRepository.
+type IngestionRunsRepositoryMockRecorder struct {
+	mock *IngestionRunsRepository
+}
+
+// NewIngestionRunsRepository creates a new mock instance.
+func NewIngestionRunsRepository(ctrl *gomock.Controller) *IngestionRunsRepository {
+	mock := &IngestionRunsRepository{ctrl: ctrl}
+	mock.recorder = &IngestionRunsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *IngestionRunsRepository) EXPECT() *IngestionRunsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetIngestionRuns mocks base method.
+func (m *IngestionRunsRepository) GetIngestionRuns(arg0 context.Context, arg1 int32) ([]*domain.IngestionRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngestionRuns", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.IngestionRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngestionRuns indicates an expected call of GetIngestionRuns.
+func (mr *IngestionRunsRepositoryMockRecorder) GetIngestionRuns(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngestionRuns", reflect.TypeOf((*IngestionRunsRepository)(nil).GetIngestionRuns), arg0, arg1)
+}
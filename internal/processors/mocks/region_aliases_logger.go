@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/donskova1ex/AverageRegionIncomes/internal/processors (interfaces: RegionAliasesLogger)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// RegionAliasesLogger is a mock of RegionAliasesLogger interface.
+type RegionAliasesLogger struct {
+	ctrl     *gomock.Controller
+	recorder *RegionAliasesLoggerMockRecorder
+}
+
+// RegionAliasesLoggerMockRecorder is the mock recorder for RegionAliasesLogger.
+type RegionAliasesLoggerMockRecorder struct {
+	mock *RegionAliasesLogger
+}
+
+// NewRegionAliasesLogger creates a new mock instance.
+func NewRegionAliasesLogger(ctrl *gomock.Controller) *RegionAliasesLogger {
+	mock := &RegionAliasesLogger{ctrl: ctrl}
+	mock.recorder = &RegionAliasesLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *RegionAliasesLogger) EXPECT() *RegionAliasesLoggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *RegionAliasesLogger) Error(arg0 string, arg1 ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *RegionAliasesLoggerMockRecorder) Error(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*RegionAliasesLogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *RegionAliasesLogger) Info(arg0 string, arg1 ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *RegionAliasesLoggerMockRecorder) Info(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*RegionAliasesLogger)(nil).Info), varargs...)
+}
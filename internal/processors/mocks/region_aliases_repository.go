@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/donskova1ex/AverageRegionIncomes/internal/processors (interfaces: RegionAliasesRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// RegionAliasesRepository is a mock of RegionAliasesRepository interface.
+type RegionAliasesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *RegionAliasesRepositoryMockRecorder
+}
+
+// RegionAliasesRepositoryMockRecorder is the mock recorder for RegionAliasesRepository.
+type RegionAliasesRepositoryMockRecorder struct {
+	mock *RegionAliasesRepository
+}
+
+// NewRegionAliasesRepository creates a new mock instance.
+func NewRegionAliasesRepository(ctrl *gomock.Controller) *RegionAliasesRepository {
+	mock := &RegionAliasesRepository{ctrl: ctrl}
+	mock.recorder = &RegionAliasesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *RegionAliasesRepository) EXPECT() *RegionAliasesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRegionAlias mocks base method.
+func (m *RegionAliasesRepository) CreateRegionAlias(arg0 context.Context, arg1 int32, arg2 string) (*domain.RegionAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRegionAlias", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.RegionAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRegionAlias indicates an expected call of CreateRegionAlias.
+func (mr *RegionAliasesRepositoryMockRecorder) CreateRegionAlias(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRegionAlias", reflect.TypeOf((*RegionAliasesRepository)(nil).CreateRegionAlias), arg0, arg1, arg2)
+}
+
+// GetRegionAliases mocks base method.
+func (m *RegionAliasesRepository) GetRegionAliases(arg0 context.Context) ([]*domain.RegionAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegionAliases", arg0)
+	ret0, _ := ret[0].([]*domain.RegionAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegionAliases indicates an expected call of GetRegionAliases.
+func (mr *RegionAliasesRepositoryMockRecorder) GetRegionAliases(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegionAliases", reflect.TypeOf((*RegionAliasesRepository)(nil).GetRegionAliases), arg0)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/donskova1ex/AverageRegionIncomes/internal/processors (interfaces: RegionsDBRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// RegionsDBRepository is a mock of RegionsDBRepository interface.
+type RegionsDBRepository struct {
+	ctrl     *gomock.Controller
+	recorder *RegionsDBRepositoryMockRecorder
+}
+
+// RegionsDBRepositoryMockRecorder is the mock recorder for RegionsDBRepository.
+type RegionsDBRepositoryMockRecorder struct {
+	mock *RegionsDBRepository
+}
+
+// NewRegionsDBRepository creates a new mock instance.
+func NewRegionsDBRepository(ctrl *gomock.Controller) *RegionsDBRepository {
+	mock := &RegionsDBRepository{ctrl: ctrl}
+	mock.recorder = &RegionsDBRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *RegionsDBRepository) EXPECT() *RegionsDBRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetRegions mocks base method.
+func (m *RegionsDBRepository) GetRegions(arg0 context.Context) ([]*domain.Regions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegions", arg0)
+	ret0, _ := ret[0].([]*domain.Regions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegions indicates an expected call of GetRegions.
+func (mr *RegionsDBRepositoryMockRecorder) GetRegions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegions", reflect.TypeOf((*RegionsDBRepository)(nil).GetRegions), arg0)
+}
@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/donskova1ex/AverageRegionIncomes/internal/processors (interfaces: RegionsLogger)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// RegionsLogger is a mock of RegionsLogger interface.
+type RegionsLogger struct {
+	ctrl     *gomock.Controller
+	recorder *RegionsLoggerMockRecorder
+}
+
+// RegionsLoggerMockRecorder is the mock recorder for RegionsLogger.
+type RegionsLoggerMockRecorder struct {
+	mock *RegionsLogger
+}
+
+// NewRegionsLogger creates a new mock instance.
+func NewRegionsLogger(ctrl *gomock.Controller) *RegionsLogger {
+	mock := &RegionsLogger{ctrl: ctrl}
+	mock.recorder = &RegionsLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *RegionsLogger) EXPECT() *RegionsLoggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *RegionsLogger) Error(arg0 string, arg1 ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *RegionsLoggerMockRecorder) Error(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*RegionsLogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *RegionsLogger) Info(arg0 string, arg1 ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *RegionsLoggerMockRecorder) Info(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*RegionsLogger)(nil).Info), varargs...)
+}
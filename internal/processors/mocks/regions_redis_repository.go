@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/donskova1ex/AverageRegionIncomes/internal/processors (interfaces: RegionsRedisRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// RegionsRedisRepository is a mock of RegionsRedisRepository interface.
+type RegionsRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *RegionsRedisRepositoryMockRecorder
+}
+
+// RegionsRedisRepositoryMockRecorder is the mock recorder for RegionsRedisRepository.
+type RegionsRedisRepositoryMockRecorder struct {
+	mock *RegionsRedisRepository
+}
+
+// NewRegionsRedisRepository creates a new mock instance.
+func NewRegionsRedisRepository(ctrl *gomock.Controller) *RegionsRedisRepository {
+	mock := &RegionsRedisRepository{ctrl: ctrl}
+	mock.recorder = &RegionsRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *RegionsRedisRepository) EXPECT() *RegionsRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCachedRegions mocks base method.
+func (m *RegionsRedisRepository) GetCachedRegions(arg0 context.Context) ([]*domain.Regions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedRegions", arg0)
+	ret0, _ := ret[0].([]*domain.Regions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedRegions indicates an expected call of GetCachedRegions.
+func (mr *RegionsRedisRepositoryMockRecorder) GetCachedRegions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedRegions", reflect.TypeOf((*RegionsRedisRepository)(nil).GetCachedRegions), arg0)
+}
+
+// SetCachedRegions mocks base method.
+func (m *RegionsRedisRepository) SetCachedRegions(arg0 context.Context, arg1 []*domain.Regions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCachedRegions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCachedRegions indicates an expected call of SetCachedRegions.
+func (mr *RegionsRedisRepositoryMockRecorder) SetCachedRegions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCachedRegions", reflect.TypeOf((*RegionsRedisRepository)(nil).SetCachedRegions), arg0, arg1)
+}
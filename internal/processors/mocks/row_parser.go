@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/donskova1ex/AverageRegionIncomes/internal/processors (interfaces: RowParser)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// RowParser is a mock of RowParser interface.
+type RowParser struct {
+	ctrl     *gomock.Controller
+	recorder *RowParserMockRecorder
+}
+
+// RowParserMockRecorder is the mock recorder for RowParser.
+type RowParserMockRecorder struct {
+	mock *RowParser
+}
+
+// NewRowParser creates a new mock instance.
+func NewRowParser(ctrl *gomock.Controller) *RowParser {
+	mock := &RowParser{ctrl: ctrl}
+	mock.recorder = &RowParserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *RowParser) EXPECT() *RowParserMockRecorder {
+	return m.recorder
+}
+
+// ParseRow mocks base method.
+func (m *RowParser) ParseRow(arg0 *domain.SourceRow) ([]*domain.ExcelRegionIncome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseRow", arg0)
+	ret0, _ := ret[0].([]*domain.ExcelRegionIncome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseRow indicates an expected call of ParseRow.
+func (mr *RowParserMockRecorder) ParseRow(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseRow", reflect.TypeOf((*RowParser)(nil).ParseRow), arg0)
+}
@@ -0,0 +1,50 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"log/slog"
+)
+
+//go:generate mockgen -destination=./mocks/region_aliases_repository.go -package=mocks -mock_names=RegionAliasesRepository=RegionAliasesRepository . RegionAliasesRepository
+type RegionAliasesRepository interface {
+	GetRegionAliases(ctx context.Context) ([]*domain.RegionAlias, error)
+	CreateRegionAlias(ctx context.Context, regionId int32, alias string) (*domain.RegionAlias, error)
+}
+
+//go:generate mockgen -destination=./mocks/region_aliases_logger.go -package=mocks -mock_names=RegionAliasesLogger=RegionAliasesLogger . RegionAliasesLogger
+type RegionAliasesLogger interface {
+	Error(msg string, args ...any)
+	Info(msg string, args ...any)
+}
+
+type regionAliases struct {
+	regionAliasesRepository RegionAliasesRepository
+	logger                  RegionAliasesLogger
+}
+
+func NewRegionAliases(regionAliasesRepository RegionAliasesRepository, log RegionAliasesLogger) *regionAliases {
+	return &regionAliases{regionAliasesRepository, log}
+}
+
+func (ra *regionAliases) GetRegionAliases(ctx context.Context) ([]*domain.RegionAlias, error) {
+	aliases, err := ra.regionAliasesRepository.GetRegionAliases(ctx)
+	if err != nil {
+		ra.logger.Error("it is impossible to get region aliases", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to get region aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+// CreateRegionAlias registers alias as another spelling of the region name, so that the next
+// ingestion resolves rows with that name to regionId.
+func (ra *regionAliases) CreateRegionAlias(ctx context.Context, regionId int32, alias string) (*domain.RegionAlias, error) {
+	regionAlias, err := ra.regionAliasesRepository.CreateRegionAlias(ctx, regionId, alias)
+	if err != nil {
+		ra.logger.Error("it is impossible to create region alias", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to create region alias: %w", err)
+	}
+	ra.logger.Info("region alias created", slog.Int("region_id", int(regionId)), slog.String("alias", regionAlias.Alias))
+	return regionAlias, nil
+}
@@ -0,0 +1,165 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"log/slog"
+	"strings"
+)
+
+//go:generate mockgen -destination=./mocks/regions_db_repository.go -package=mocks -mock_names=RegionsDBRepository=RegionsDBRepository . RegionsDBRepository
+type RegionsDBRepository interface {
+	GetRegions(ctx context.Context) ([]*domain.Regions, error)
+}
+
+//go:generate mockgen -destination=./mocks/regions_redis_repository.go -package=mocks -mock_names=RegionsRedisRepository=RegionsRedisRepository . RegionsRedisRepository
+type RegionsRedisRepository interface {
+	GetCachedRegions(ctx context.Context) ([]*domain.Regions, error)
+	SetCachedRegions(ctx context.Context, regions []*domain.Regions) error
+}
+
+//go:generate mockgen -destination=./mocks/regions_logger.go -package=mocks -mock_names=RegionsLogger=RegionsLogger . RegionsLogger
+type RegionsLogger interface {
+	Error(msg string, args ...any)
+	Info(msg string, args ...any)
+}
+
+type regions struct {
+	regionsRepository      RegionsDBRepository
+	regionsRedisRepository RegionsRedisRepository
+	logger                 RegionsLogger
+}
+
+func NewRegions(regionsRepository RegionsDBRepository, regionsRedisRepository RegionsRedisRepository, log RegionsLogger) *regions {
+	return &regions{regionsRepository, regionsRedisRepository, log}
+}
+
+// GetRegions returns the region directory. A non-empty query keeps only the regions whose
+// name contains it, ignoring case and spaces.
+func (r *regions) GetRegions(ctx context.Context, query string) ([]*domain.Regions, error) {
+	allRegions, err := r.getRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedQuery := domain.NormalizeRegionName(query)
+	if normalizedQuery == "" {
+		return allRegions, nil
+	}
+
+	found := make([]*domain.Regions, 0)
+	for _, region := range allRegions {
+		if strings.Contains(domain.NormalizeRegionName(region.RegionName), normalizedQuery) {
+			found = append(found, region)
+		}
+	}
+	return found, nil
+}
+
+func (r *regions) GetRegion(ctx context.Context, regionId int32) (*domain.Regions, error) {
+	allRegions, err := r.getRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, region := range allRegions {
+		if region.RegionId == regionId {
+			return region, nil
+		}
+	}
+	return nil, fmt.Errorf("region not found with region_id [%d]: %w", regionId, domain.ErrNotFound)
+}
+
+// ResolveRegionIds maps region codes (OKATO, OKTMO or ISO 3166-2:RU) and region names to
+// region ids, in the order given. A name matches when it equals a region name ignoring case and
+// spaces or, failing that, is contained in exactly one region name. An identifier matching no
+// region is not found, one matching several regions is an invalid parameter.
+func (r *regions) ResolveRegionIds(ctx context.Context, codes []string, names []string) ([]int32, error) {
+	if len(codes) == 0 && len(names) == 0 {
+		return nil, nil
+	}
+
+	allRegions, err := r.getRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	regionIds := make([]int32, 0, len(codes)+len(names))
+	for _, code := range codes {
+		region, err := resolveRegion(allRegions, "regioncode", code, func(region *domain.Regions) bool {
+			return region.HasCode(code)
+		})
+		if err != nil {
+			return nil, err
+		}
+		regionIds = append(regionIds, region.RegionId)
+	}
+
+	for _, name := range names {
+		normalizedName := domain.NormalizeRegionName(name)
+		region, err := resolveRegion(allRegions, "regionname", name, func(region *domain.Regions) bool {
+			return domain.NormalizeRegionName(region.RegionName) == normalizedName
+		})
+		if errors.Is(err, domain.ErrNotFound) && normalizedName != "" {
+			region, err = resolveRegion(allRegions, "regionname", name, func(region *domain.Regions) bool {
+				return strings.Contains(domain.NormalizeRegionName(region.RegionName), normalizedName)
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		regionIds = append(regionIds, region.RegionId)
+	}
+
+	return regionIds, nil
+}
+
+// resolveRegion returns the only region accepted by match.
+func resolveRegion(allRegions []*domain.Regions, field string, identifier string, match func(region *domain.Regions) bool) (*domain.Regions, error) {
+	var found []*domain.Regions
+	for _, region := range allRegions {
+		if match(region) {
+			found = append(found, region)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, domain.NewFieldError(domain.ErrNotFound, field, fmt.Sprintf("no region matches [%s]", identifier))
+	case 1:
+		return found[0], nil
+	default:
+		names := make([]string, 0, len(found))
+		for _, region := range found {
+			names = append(names, fmt.Sprintf("%s (%d)", region.RegionName, region.RegionId))
+		}
+		return nil, domain.NewFieldError(domain.ErrInvalidParameter, field, fmt.Sprintf("[%s] is ambiguous, it matches: %s", identifier, strings.Join(names, ", ")))
+	}
+}
+
+// getRegions serves the whole directory from Redis, loading and caching it on a miss.
+// The directory is small, so lookups and searches filter the cached list.
+func (r *regions) getRegions(ctx context.Context) ([]*domain.Regions, error) {
+	cachedRegions, err := r.regionsRedisRepository.GetCachedRegions(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("it is impossible to get a cached regions: %w", err)
+	}
+	if cachedRegions != nil {
+		return cachedRegions, nil
+	}
+
+	allRegions, err := r.regionsRepository.GetRegions(ctx)
+	if err != nil {
+		r.logger.Error("it is impossible to get a regions", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to get a regions: %w", err)
+	}
+	err = r.regionsRedisRepository.SetCachedRegions(ctx, allRegions)
+	if err != nil {
+		r.logger.Error("it is impossible to set cached regions", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("it is impossible to set cached regions: %w", err)
+	}
+	return allRegions, nil
+}
@@ -0,0 +1,110 @@
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/processors/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RegionsTestSuite struct {
+	suite.Suite
+	ctrl            *gomock.Controller
+	processor       *regions
+	repository      *mocks.RegionsDBRepository
+	redisRepository *mocks.RegionsRedisRepository
+	logger          *mocks.RegionsLogger
+	ctx             context.Context
+	regions         []*domain.Regions
+}
+
+func (s *RegionsTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.repository = mocks.NewRegionsDBRepository(s.ctrl)
+	s.redisRepository = mocks.NewRegionsRedisRepository(s.ctrl)
+	s.logger = mocks.NewRegionsLogger(s.ctrl)
+	s.processor = NewRegions(s.repository, s.redisRepository, s.logger)
+	s.ctx = context.Background()
+	s.regions = []*domain.Regions{
+		{RegionId: 1, RegionName: "Республика Адыгея", OkatoCode: "79000000000", OktmoCode: "79000000", IsoCode: "RU-AD"},
+		{RegionId: 7, RegionName: "Кабардино-Балкарская  Республика", OkatoCode: "83000000000", OktmoCode: "83000000", IsoCode: "RU-KB"},
+		{RegionId: 77, RegionName: "г. Москва", OkatoCode: "45000000000", OktmoCode: "45000000", IsoCode: "RU-MOW"},
+	}
+}
+
+func (s *RegionsTestSuite) TestGetRegionsCacheMiss() {
+	gomock.InOrder(
+		s.redisRepository.
+			EXPECT().
+			GetCachedRegions(gomock.Any()).
+			Return(nil, redis.Nil),
+		s.repository.
+			EXPECT().
+			GetRegions(gomock.Any()).
+			Return(s.regions, nil),
+		s.redisRepository.
+			EXPECT().
+			SetCachedRegions(gomock.Any(), s.regions).
+			Return(nil),
+	)
+
+	result, err := s.processor.GetRegions(s.ctx, "")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), s.regions, result)
+}
+
+func (s *RegionsTestSuite) TestGetRegionsSearchIgnoresCaseAndSpaces() {
+	s.redisRepository.
+		EXPECT().
+		GetCachedRegions(gomock.Any()).
+		Return(s.regions, nil)
+
+	result, err := s.processor.GetRegions(s.ctx, "балкарская РЕСПУБЛИКА")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), result, 1)
+	require.Equal(s.T(), int32(7), result[0].RegionId)
+}
+
+func (s *RegionsTestSuite) TestGetRegionNotFound() {
+	s.redisRepository.
+		EXPECT().
+		GetCachedRegions(gomock.Any()).
+		Return(s.regions, nil)
+
+	_, err := s.processor.GetRegion(s.ctx, 99)
+	require.ErrorIs(s.T(), err, domain.ErrNotFound)
+}
+
+func (s *RegionsTestSuite) TestResolveRegionIdsByCodeAndName() {
+	s.redisRepository.
+		EXPECT().
+		GetCachedRegions(gomock.Any()).
+		Return(s.regions, nil)
+
+	result, err := s.processor.ResolveRegionIds(s.ctx, []string{"ru-mow", "79"}, []string{"кабардино-балкарская республика", "адыгея"})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []int32{77, 1, 7, 1}, result)
+}
+
+func (s *RegionsTestSuite) TestResolveRegionIdsAmbiguousName() {
+	s.redisRepository.
+		EXPECT().
+		GetCachedRegions(gomock.Any()).
+		Return(s.regions, nil)
+
+	_, err := s.processor.ResolveRegionIds(s.ctx, nil, []string{"республика"})
+
+	var fieldErr *domain.FieldError
+	require.ErrorAs(s.T(), err, &fieldErr)
+	require.Equal(s.T(), "regionname", fieldErr.Field)
+	require.ErrorIs(s.T(), err, domain.ErrInvalidParameter)
+}
+
+func TestRegionsTestSuite(t *testing.T) {
+	suite.Run(t, new(RegionsTestSuite))
+}
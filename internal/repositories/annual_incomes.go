@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+)
+
+// GetRegionAnnualIncomes returns the annual total of the latest download of every year between fromYear and
+// toYear inclusive, oldest first. A zero bound leaves that side of the range open.
+func (r *SQLRepository) GetRegionAnnualIncomes(ctx context.Context, regionId int32, fromYear int32, toYear int32) ([]*domain.RegionAnnualIncome, error) {
+	regionAnnualIncomes := make([]*domain.RegionAnnualIncome, 0)
+
+	query := `SELECT DISTINCT ON (year)
+					region_id,
+					year,
+					value,
+					loaded_at
+				FROM region_annual_incomes
+				WHERE region_id = $1
+					AND ($2 = 0 OR year >= $2)
+					AND ($3 = 0 OR year <= $3)
+				ORDER BY year, downloaded_at DESC, loaded_at DESC`
+
+	err := r.db.SelectContext(ctx, &regionAnnualIncomes, query, regionId, fromYear, toYear)
+	if err != nil {
+		return nil, fmt.Errorf("err getting annual incomes by region_id [%d], from [%d], to [%d]: %w", regionId, fromYear, toYear, classifyDBError(err))
+	}
+	if len(regionAnnualIncomes) == 0 {
+		return nil, fmt.Errorf("annual incomes not found with region_id [%d], from [%d], to [%d]: %w", regionId, fromYear, toYear, domain.ErrNotFound)
+	}
+
+	return regionAnnualIncomes, nil
+}
+
+func (r *RedisRepository) GetCachedRegionAnnualIncomes(ctx context.Context, regionId int32, fromYear int32, toYear int32) ([]*domain.RegionAnnualIncome, error) {
+	var regionAnnualIncomesJSON string
+
+	err := r.db.Get(ctx, createAnnualIncomesCachedKey(regionId, fromYear, toYear)).Scan(&regionAnnualIncomesJSON)
+	if err != nil {
+		return nil, fmt.Errorf("error getting cached annual incomes: %w", classifyRedisError(err))
+	}
+
+	regionAnnualIncomes := make([]*domain.RegionAnnualIncome, 0)
+	err = json.Unmarshal([]byte(regionAnnualIncomesJSON), &regionAnnualIncomes)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling cached annual incomes: %w", err)
+	}
+	r.logger.Info("get cached annual incomes", slog.String("region_id", fmt.Sprintf("%d", regionId)), slog.String("from", fmt.Sprintf("%d", fromYear)), slog.String("to", fmt.Sprintf("%d", toYear)))
+	return regionAnnualIncomes, nil
+}
+
+func (r *RedisRepository) SetCachedRegionAnnualIncomes(
+	ctx context.Context,
+	regionAnnualIncomes []*domain.RegionAnnualIncome,
+	regionId int32,
+	fromYear int32,
+	toYear int32) error {
+
+	regionAnnualIncomesJSON, err := json.Marshal(regionAnnualIncomes)
+	if err != nil {
+		return fmt.Errorf("error marshalling cached annual incomes: %w", err)
+	}
+
+	err = r.db.Set(ctx, createAnnualIncomesCachedKey(regionId, fromYear, toYear), regionAnnualIncomesJSON, r.ttl).Err()
+	if err != nil {
+		return fmt.Errorf("error setting cached annual incomes: %w", classifyRedisError(err))
+	}
+	r.logger.Info("set cached annual incomes", slog.String("region_id", fmt.Sprintf("%d", regionId)), slog.String("from", fmt.Sprintf("%d", fromYear)), slog.String("to", fmt.Sprintf("%d", toYear)))
+	return nil
+}
+
+func createAnnualIncomesCachedKey(regionId int32, fromYear int32, toYear int32) string {
+	return fmt.Sprintf("region_annual_incomes_%d_%d_%d", regionId, fromYear, toYear)
+}
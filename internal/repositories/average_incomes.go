@@ -8,21 +8,24 @@ import (
 	"fmt"
 	"github.com/lib/pq"
 	"log/slog"
-	"strings"
+	"sort"
+	"time"
 
 	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
 	"github.com/jmoiron/sqlx"
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
 )
 
-func (r *SQLRepository) CreateRegionIncomes(ctx context.Context, exRegionIncomes []*domain.ExcelRegionIncome) error {
+func (r *SQLRepository) CreateRegionIncomes(ctx context.Context, exRegionIncomes []*domain.ExcelRegionIncome) (*domain.IngestionResult, error) {
 	const maxRetries = 5
 	var lastErr error
 
 	for attempt := 0; attempt < maxRetries; attempt++ {
-		err := r.createRegionIncomesWithTx(ctx, exRegionIncomes)
+		result, err := r.createRegionIncomesWithTx(ctx, exRegionIncomes)
 		if err == nil {
 			r.logger.Info("Re")
-			return nil
+			return result, nil
 		}
 
 		var pqErr *pq.Error
@@ -37,14 +40,22 @@ func (r *SQLRepository) CreateRegionIncomes(ctx context.Context, exRegionIncomes
 		r.logger.Error("non-retryable error on attempt",
 			slog.Int("attempt", attempt+1),
 			slog.String("err", err.Error()))
-		return fmt.Errorf("non-retryable error on attempt %d: %w", attempt+1, err)
+		return nil, fmt.Errorf("non-retryable error on attempt %d: %w", attempt+1, err)
 	}
 
-	return fmt.Errorf("failed after %d attempts: %w", maxRetries, lastErr)
+	return nil, fmt.Errorf("failed after %d attempts: %w", maxRetries, lastErr)
 }
 
+// getRegionsMap maps normalized region names and aliases to region ids. When a name and an
+// alias of different regions normalize to the same key, the region name wins.
 func (r *SQLRepository) getRegionsMap(ctx context.Context, tx *sqlx.Tx) (map[string]int32, error) {
-	query := `SELECT region_id, region_name FROM regions`
+	query := `SELECT region_id, name
+				FROM (
+					SELECT region_id, region_name AS name, 0 AS priority FROM regions
+					UNION ALL
+					SELECT region_id, alias AS name, 1 AS priority FROM region_aliases
+				) AS names
+				ORDER BY priority, region_id`
 
 	rows, err := tx.QueryxContext(ctx, query)
 	if err != nil {
@@ -61,8 +72,17 @@ func (r *SQLRepository) getRegionsMap(ctx context.Context, tx *sqlx.Tx) (map[str
 		if err := rows.Scan(&regionID, &regionName); err != nil {
 			return nil, fmt.Errorf("error scanning row: %w", err)
 		}
-		trimmedRegionName := strings.ReplaceAll(regionName, " ", "")
-		regionsMap[trimmedRegionName] = regionID
+		normalizedName := domain.NormalizeRegionName(regionName)
+		if existingID, ok := regionsMap[normalizedName]; ok {
+			if existingID != regionID {
+				r.logger.Warn("region name matches several regions, keeping the first one",
+					slog.String("name", regionName),
+					slog.Int("region_id", int(existingID)),
+					slog.Int("skipped_region_id", int(regionID)))
+			}
+			continue
+		}
+		regionsMap[normalizedName] = regionID
 	}
 
 	if err := rows.Err(); err != nil {
@@ -72,7 +92,80 @@ func (r *SQLRepository) getRegionsMap(ctx context.Context, tx *sqlx.Tx) (map[str
 	return regionsMap, nil
 }
 
-func (r *SQLRepository) createRegionIncomesWithTx(ctx context.Context, exRegionIncomes []*domain.ExcelRegionIncome) error {
+// getRegionCodesMap maps the normalized OKATO, OKTMO and ISO 3166-2:RU codes of the regions to
+// region ids. A code shared by several regions is kept for the first one, OKATO codes first.
+func (r *SQLRepository) getRegionCodesMap(ctx context.Context, tx *sqlx.Tx) (map[string]int32, error) {
+	query := `SELECT region_id, code
+				FROM (
+					SELECT region_id, okato_code AS code, 0 AS priority FROM regions
+					UNION ALL
+					SELECT region_id, oktmo_code AS code, 1 AS priority FROM regions
+					UNION ALL
+					SELECT region_id, iso_code AS code, 2 AS priority FROM regions
+				) AS codes
+				WHERE COALESCE(code, '') <> ''
+				ORDER BY priority, region_id`
+
+	rows, err := tx.QueryxContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	codesMap := make(map[string]int32)
+
+	for rows.Next() {
+		var regionID int32
+		var code string
+
+		if err := rows.Scan(&regionID, &code); err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		normalizedCode := domain.NormalizeRegionCode(code)
+		if existingID, ok := codesMap[normalizedCode]; ok {
+			if existingID != regionID {
+				r.logger.Warn("region code matches several regions, keeping the first one",
+					slog.String("code", code),
+					slog.Int("region_id", int(existingID)),
+					slog.Int("skipped_region_id", int(regionID)))
+			}
+			continue
+		}
+		codesMap[normalizedCode] = regionID
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+
+	return codesMap, nil
+}
+
+// resolveRegion finds the region of a parsed value: by its code when the source gives one,
+// otherwise by its name or alias. A name that is neither is tried as a code, which is what the
+// region cell of a re-processed row of a code-based source holds.
+func resolveRegion(regionsMap, codesMap map[string]int32, income *domain.ExcelRegionIncome) (int32, bool) {
+	if income.RegionCode != "" {
+		regionID, ok := codesMap[domain.NormalizeRegionCode(income.RegionCode)]
+		return regionID, ok
+	}
+	if regionID, ok := regionsMap[domain.NormalizeRegionName(income.Region)]; ok {
+		return regionID, true
+	}
+	regionID, ok := codesMap[domain.NormalizeRegionCode(income.Region)]
+	return regionID, ok
+}
+
+// downloadedAt is the download time of the source of a value, or the time of loading for
+// values whose source does not tell, such as re-processed rows.
+func downloadedAt(income *domain.ExcelRegionIncome, loadedAt time.Time) time.Time {
+	if income.DownloadedAt.IsZero() {
+		return loadedAt
+	}
+	return income.DownloadedAt
+}
+
+func (r *SQLRepository) createRegionIncomesWithTx(ctx context.Context, exRegionIncomes []*domain.ExcelRegionIncome) (*domain.IngestionResult, error) {
 	var txCommited bool
 
 	serializableIsolation := &sql.TxOptions{
@@ -81,7 +174,7 @@ func (r *SQLRepository) createRegionIncomesWithTx(ctx context.Context, exRegionI
 	}
 	tx, err := r.db.BeginTxx(ctx, serializableIsolation)
 	if err != nil {
-		return fmt.Errorf("error starting transaction: %w", err)
+		return nil, fmt.Errorf("error starting transaction: %w", err)
 	}
 
 	defer func() {
@@ -94,48 +187,142 @@ func (r *SQLRepository) createRegionIncomesWithTx(ctx context.Context, exRegionI
 
 	regionsMap, err := r.getRegionsMap(ctx, tx)
 	if err != nil {
-		return fmt.Errorf("error filling regions map: %w", err)
+		return nil, fmt.Errorf("error filling regions map: %w", err)
+	}
+	codesMap, err := r.getRegionCodesMap(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("error filling region codes map: %w", err)
 	}
 
+	loadedAt := time.Now()
 	regionIncomes := make([]*domain.RegionIncomes, 0, len(exRegionIncomes))
+	annualIncomes := make([]*domain.RegionAnnualIncome, 0)
+	unresolvedRegions := make(map[string]bool)
+	unresolvedRows := make(map[*domain.SourceRow]bool)
+	loadedRows := make(map[*domain.SourceRow]bool)
+	rejects := make([]*domain.IngestionReject, 0)
 	for _, region := range exRegionIncomes {
-		trimmedRegionName := strings.ReplaceAll(region.Region, " ", "")
-		if regionID, ok := regionsMap[trimmedRegionName]; ok {
-			regionIncomes = append(regionIncomes, &domain.RegionIncomes{
-				RegionId: regionID,
-				Value:    region.AverageRegionIncomes,
-				Year:     region.Year,
-				Quarter:  region.Quarter,
+		regionID, ok := resolveRegion(regionsMap, codesMap, region)
+		if !ok {
+			unresolvedRegions[region.Region] = true
+			if region.Source != nil && !unresolvedRows[region.Source] {
+				unresolvedRows[region.Source] = true
+				reason := fmt.Sprintf("region [%s] does not match any region name, alias or code", region.Region)
+				if region.RegionCode != "" {
+					reason = fmt.Sprintf("region code [%s] does not match any region", region.RegionCode)
+				}
+				rejects = append(rejects, &domain.IngestionReject{
+					Row:    region.Source,
+					Reason: reason,
+				})
+			}
+			continue
+		}
+		if region.Source != nil {
+			loadedRows[region.Source] = true
+		}
+		if region.PeriodType == domain.PeriodYear {
+			// A missing annual total carries nothing to compare or report, so it is not stored.
+			if region.Missing {
+				continue
+			}
+			annualIncomes = append(annualIncomes, &domain.RegionAnnualIncome{
+				RegionId:     regionID,
+				Year:         region.Year,
+				Value:        region.AverageRegionIncomes,
+				DownloadedAt: downloadedAt(region, loadedAt),
 			})
+			continue
 		}
+		regionIncome := &domain.RegionIncomes{
+			RegionId:     regionID,
+			Value:        decimal.NewNullDecimal(region.AverageRegionIncomes),
+			Year:         region.Year,
+			Quarter:      region.Quarter,
+			DownloadedAt: downloadedAt(region, loadedAt),
+		}
+		if region.Missing {
+			regionIncome.Value = decimal.NullDecimal{}
+			regionIncome.MissingMarker = &region.MissingMarker
+		}
+		regionIncomes = append(regionIncomes, regionIncome)
+	}
+
+	result := &domain.IngestionResult{
+		RowsRead:          len(exRegionIncomes),
+		RowsRejected:      len(rejects),
+		UnresolvedRegions: make([]string, 0, len(unresolvedRegions)),
+	}
+	for regionName := range unresolvedRegions {
+		result.UnresolvedRegions = append(result.UnresolvedRegions, regionName)
 	}
+	sort.Strings(result.UnresolvedRegions)
 
-	query := `
-        INSERT INTO region_incomes (region_id, year, quarter, value) 
-        VALUES (:region_id, :year, :quarter, :value)
+	if len(regionIncomes) > 0 {
+		query := `
+        INSERT INTO region_incomes (region_id, year, quarter, value, missing_marker, downloaded_at) 
+        VALUES (:region_id, :year, :quarter, :value, :missing_marker, :downloaded_at)
         ON CONFLICT (region_id, year, quarter, value) DO NOTHING`
 
-	result, err := tx.NamedExec(query, regionIncomes)
-	if err != nil {
-		r.logger.Error("error executing query", slog.String("err", err.Error()))
-		return fmt.Errorf("error executing query: %w", err)
+		execResult, err := tx.NamedExec(query, regionIncomes)
+		if err != nil {
+			r.logger.Error("error executing query", slog.String("err", err.Error()))
+			return nil, fmt.Errorf("error executing query: %w", err)
+		}
+
+		result.RowsInserted, err = execResult.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		r.logger.Info("rows inserted", slog.Int("count", int(result.RowsInserted)))
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	if len(annualIncomes) > 0 {
+		query := `
+        INSERT INTO region_annual_incomes (region_id, year, value, downloaded_at) 
+        VALUES (:region_id, :year, :value, :downloaded_at)
+        ON CONFLICT (region_id, year, value) DO NOTHING`
+
+		execResult, err := tx.NamedExec(query, annualIncomes)
+		if err != nil {
+			r.logger.Error("error executing query", slog.String("err", err.Error()))
+			return nil, fmt.Errorf("error inserting annual incomes: %w", err)
+		}
+
+		result.AnnualRowsInserted, err = execResult.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		r.logger.Info("annual rows inserted", slog.Int("count", int(result.AnnualRowsInserted)))
+	}
+
+	if err := upsertIngestionRejects(ctx, tx, rejects); err != nil {
+		return nil, fmt.Errorf("error quarantining rows with unresolved regions: %w", err)
+	}
+
+	resolvedRows := make([]*domain.SourceRow, 0, len(loadedRows))
+	for row := range loadedRows {
+		if !unresolvedRows[row] {
+			resolvedRows = append(resolvedRows, row)
+		}
+	}
+	resolvedRejects, err := resolveIngestionRejects(ctx, tx, resolvedRows)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return nil, fmt.Errorf("error resolving quarantined rows: %w", err)
+	}
+	if resolvedRejects > 0 {
+		r.logger.Info("quarantined rows resolved", slog.Int("count", int(resolvedRejects)))
 	}
-	r.logger.Info("rows inserted", slog.Int("count", int(rowsAffected)))
 
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("error committing transaction: %w", err)
+		return nil, fmt.Errorf("error committing transaction: %w", err)
 	}
 	txCommited = true
 
-	return nil
+	return result, nil
 }
 
-func (r *SQLRepository) GetRegionIncomes(ctx context.Context, regionId int32, year int32, quarter int32) (*domain.AverageRegionIncomes, error) {
+func (r *SQLRepository) GetRegionIncomes(ctx context.Context, regionIds []int32, year int32, quarter int32, averaging domain.Averaging) ([]*domain.AverageRegionIncomes, error) {
 	var txCommited bool
 
 	readOnlyTx := &sql.TxOptions{
@@ -145,7 +332,7 @@ func (r *SQLRepository) GetRegionIncomes(ctx context.Context, regionId int32, ye
 
 	tx, err := r.db.BeginTxx(ctx, readOnlyTx)
 	if err != nil {
-		return nil, fmt.Errorf("error starting transaction: %w", err)
+		return nil, fmt.Errorf("error starting transaction: %w", classifyDBError(err))
 	}
 
 	defer func() {
@@ -156,15 +343,17 @@ func (r *SQLRepository) GetRegionIncomes(ctx context.Context, regionId int32, ye
 		}
 	}()
 
-	var result *domain.AverageRegionIncomes
+	var result []*domain.AverageRegionIncomes
 	var queryErr error
 
-	if year == 0 && quarter == 0 {
-		result, queryErr = r.getIncomesByRegionID(ctx, tx, regionId)
+	if averaging.Mode == domain.AveragingCalendar {
+		result, queryErr = r.getCalendarYearIncomes(ctx, tx, regionIds, year, averaging)
+	} else if year == 0 && quarter == 0 {
+		result, queryErr = r.getIncomesByRegionID(ctx, tx, regionIds, averaging)
 	} else if quarter == 0 {
-		result, queryErr = r.getIncomesByRegionIDAndYear(ctx, tx, regionId, year)
+		result, queryErr = r.getIncomesByRegionIDAndYear(ctx, tx, regionIds, year, averaging)
 	} else {
-		result, queryErr = r.getRegionIncomesByAllParameters(ctx, tx, regionId, year, quarter)
+		result, queryErr = r.getRegionIncomesByAllParameters(ctx, tx, regionIds, year, quarter, averaging)
 	}
 
 	if queryErr != nil {
@@ -178,187 +367,337 @@ func (r *SQLRepository) GetRegionIncomes(ctx context.Context, regionId int32, ye
 	return result, nil
 }
 
-func (r *SQLRepository) getIncomesByRegionID(ctx context.Context, tx *sqlx.Tx, regionId int32) (*domain.AverageRegionIncomes, error) {
-	averageRegionIncomes := &domain.AverageRegionIncomes{}
+// regionIdsFilter matches every region when the requested list is empty,
+// otherwise only the listed ones. It expects the region list as $1.
+const regionIdsFilter = `(COALESCE(cardinality($1::int[]), 0) = 0 OR region_id = ANY($1::int[]))`
+
+// averageExpression returns the aggregate computing the average over the alias' value and rn
+// (recency rank, 1 for the newest quarter) columns. windowParam is the placeholder holding the window.
+func averageExpression(method domain.AveragingMethod, alias string, windowParam string) string {
+	switch method {
+	case domain.AveragingMedian:
+		// Mean of the two middle values (the same one for odd counts), kept in exact numeric
+		// arithmetic instead of PERCENTILE_CONT, which works in double precision.
+		return fmt.Sprintf(`((ARRAY_AGG(%[1]s.value ORDER BY %[1]s.value))[FLOOR((COUNT(*) + 1) / 2.0)::int]
+						+ (ARRAY_AGG(%[1]s.value ORDER BY %[1]s.value))[CEIL((COUNT(*) + 1) / 2.0)::int]) / 2`, alias)
+	case domain.AveragingWeighted:
+		weight := fmt.Sprintf("(%s::int + 1 - %s.rn)", windowParam, alias)
+		return fmt.Sprintf("SUM(%s.value * %s) / SUM(%s)", alias, weight, weight)
+	default:
+		return fmt.Sprintf("AVG(%s.value)", alias)
+	}
+}
+
+// lastPeriodColumns reports the newest quarter used in the average as the response year and quarter.
+func lastPeriodColumns(alias string) string {
+	return fmt.Sprintf(`(ARRAY_AGG(%[1]s.year ORDER BY %[1]s.year DESC, %[1]s.quarter DESC))[1] AS year,
+					(ARRAY_AGG(%[1]s.quarter ORDER BY %[1]s.year DESC, %[1]s.quarter DESC))[1] AS quarter`, alias)
+}
+
+// coveredPeriodColumns describes the quarters actually used in the average: the oldest and the newest
+// one, how many there were, whether that is as many as expectedParam and when the most recently
+// loaded of them was stored. Quarters the source did not publish still take their place in the
+// window but are filtered out before aggregation, so they are not counted and leave the average
+// incomplete.
+func coveredPeriodColumns(alias string, expectedParam string) string {
+	return fmt.Sprintf(`(ARRAY_AGG(%[1]s.year ORDER BY %[1]s.year, %[1]s.quarter))[1] AS first_year,
+					(ARRAY_AGG(%[1]s.quarter ORDER BY %[1]s.year, %[1]s.quarter))[1] AS first_quarter,
+					(ARRAY_AGG(%[1]s.year ORDER BY %[1]s.year DESC, %[1]s.quarter DESC))[1] AS last_year,
+					(ARRAY_AGG(%[1]s.quarter ORDER BY %[1]s.year DESC, %[1]s.quarter DESC))[1] AS last_quarter,
+					COUNT(*) AS quarters_count,
+					COUNT(*) = %[2]s::int AS complete,
+					MAX(%[1]s.loaded_at) AS loaded_at`, alias, expectedParam)
+}
+
+func (r *SQLRepository) getIncomesByRegionID(ctx context.Context, tx *sqlx.Tx, regionIds []int32, averaging domain.Averaging) ([]*domain.AverageRegionIncomes, error) {
+	averageRegionIncomes := make([]*domain.AverageRegionIncomes, 0, len(regionIds))
 
 	query := `SELECT
-					R.REGION_NAME AS REGION_NAME,
-					RI.REGION_ID AS REGION_ID,
-					EXTRACT(YEAR FROM CURRENT_DATE) AS YEAR,
-					FLOOR((EXTRACT(MONTH FROM CURRENT_DATE) - 1) / 3) + 1 AS QUARTER,
-					AVG(RI.VALUE) AS AVERAGE_REGION_INCOMES
-				FROM
-					(
-					SELECT REGION_ID, VALUE
-					FROM
-						(SELECT DISTINCT ON (QUARTER, YEAR)
-							REGION_ID,
-							VALUE,
-							LOADED_AT,
-							QUARTER,
-							YEAR
-						FROM
-							REGION_INCOMES
-						WHERE
-							REGION_ID = $1
-						ORDER BY
-							QUARTER DESC,
-							YEAR DESC,
-							LOADED_AT DESC
-				) AS LATEST_QUARTERS
-					ORDER BY
-						YEAR DESC,
-						QUARTER DESC
-					LIMIT 4) AS RI
-				JOIN REGIONS R ON
-					RI.REGION_ID = R.REGION_ID
-				GROUP BY
-					R.REGION_NAME,
-					RI.REGION_ID`
+					r.region_name AS region_name,
+					ri.region_id AS region_id,
+					` + lastPeriodColumns("ri") + `,
+					` + averageExpression(averaging.Method, "ri", "$2") + ` AS average_region_incomes,
+					` + coveredPeriodColumns("ri", "$2") + `
+				FROM (
+					SELECT
+						region_id,
+						year,
+						quarter,
+						value,
+						loaded_at,
+						ROW_NUMBER() OVER (PARTITION BY region_id ORDER BY year DESC, quarter DESC) AS rn
+					FROM (
+						SELECT DISTINCT ON (region_id, year, quarter)
+							region_id,
+							year,
+							quarter,
+							value,
+							loaded_at
+						FROM region_incomes
+						WHERE ` + regionIdsFilter + `
+						ORDER BY region_id, year DESC, quarter DESC, downloaded_at DESC, loaded_at DESC
+					) AS latest_quarters
+				) AS ri
+				JOIN regions r ON ri.region_id = r.region_id
+				WHERE ri.rn <= $2::int
+				  AND ri.value IS NOT NULL
+				GROUP BY r.region_name, ri.region_id
+				ORDER BY ri.region_id`
 
-	err := tx.GetContext(ctx, averageRegionIncomes, query, regionId)
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, fmt.Errorf("region not found with region_id [%d]: %w", regionId, err)
-	}
+	err := tx.SelectContext(ctx, &averageRegionIncomes, query, pq.Array(regionIds), averaging.Window)
 	if err != nil {
-		return nil, fmt.Errorf("err getting incomes by region_id [%d]: %w", regionId, err)
+		return nil, fmt.Errorf("err getting incomes by region_ids %v: %w", regionIds, classifyDBError(err))
+	}
+	if len(averageRegionIncomes) == 0 {
+		return nil, fmt.Errorf("regions not found with region_ids %v: %w", regionIds, domain.ErrNotFound)
 	}
 
 	return averageRegionIncomes, nil
 }
 
-func (r *SQLRepository) getIncomesByRegionIDAndYear(ctx context.Context, tx *sqlx.Tx, regionId int32, year int32) (*domain.AverageRegionIncomes, error) {
-	averageRegionIncomes := &domain.AverageRegionIncomes{}
-	query := `SELECT 
-					r.region_name AS Region_Name,
-					ri.region_id AS Region_Id,
-					EXTRACT(YEAR FROM CURRENT_DATE) AS Year,
-					FLOOR((EXTRACT(MONTH FROM CURRENT_DATE) - 1) / 3) + 1 AS Quarter,
-					AVG(ri.value) AS Average_Region_Incomes
+// getIncomesByRegionIDAndYear averages the newest quarters up to the end of year, looking back
+// as many years as the window spans plus one, like the four-quarter window looks at year and year-1.
+func (r *SQLRepository) getIncomesByRegionIDAndYear(ctx context.Context, tx *sqlx.Tx, regionIds []int32, year int32, averaging domain.Averaging) ([]*domain.AverageRegionIncomes, error) {
+	averageRegionIncomes := make([]*domain.AverageRegionIncomes, 0, len(regionIds))
+
+	query := `SELECT
+					r.region_name AS region_name,
+					ri.region_id AS region_id,
+					` + lastPeriodColumns("ri") + `,
+					` + averageExpression(averaging.Method, "ri", "$3") + ` AS average_region_incomes,
+					` + coveredPeriodColumns("ri", "$3") + `
 				FROM (
-					SELECT region_id, value
+					SELECT
+						region_id,
+						year,
+						quarter,
+						value,
+						loaded_at,
+						ROW_NUMBER() OVER (PARTITION BY region_id ORDER BY year DESC, quarter DESC) AS rn
 					FROM (
-						SELECT DISTINCT ON (year, quarter)
+						SELECT DISTINCT ON (region_id, year, quarter)
 							region_id,
 							year,
 							quarter,
 							value,
 							loaded_at
 						FROM region_incomes
-						WHERE region_id = $1
-						  AND (
-							  year = $2
-							  OR year = $2 - 1
-						  )
-						ORDER BY year DESC, quarter DESC, loaded_at DESC
+						WHERE ` + regionIdsFilter + `
+						  AND year <= $2
+						  AND year >= $2 - CEIL($3::int / 4.0)::int
+						ORDER BY region_id, year DESC, quarter DESC, downloaded_at DESC, loaded_at DESC
 					) AS latest_quarters
-					ORDER BY year DESC, quarter DESC
-					LIMIT 4
 				) AS ri
 				JOIN regions r ON ri.region_id = r.region_id
-				GROUP BY r.region_name, ri.region_id`
+				WHERE ri.rn <= $3::int
+				  AND ri.value IS NOT NULL
+				GROUP BY r.region_name, ri.region_id
+				ORDER BY ri.region_id`
 
-	err := tx.GetContext(ctx, averageRegionIncomes, query, regionId, year)
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, fmt.Errorf("region not found with region_id [%d], year [%d]: %w", regionId, year, err)
-	}
+	err := tx.SelectContext(ctx, &averageRegionIncomes, query, pq.Array(regionIds), year, averaging.Window)
 	if err != nil {
-		return nil, fmt.Errorf("err getting incomes by region_id [%d], year [%d]: %w", regionId, year, err)
+		return nil, fmt.Errorf("err getting incomes by region_ids %v, year [%d]: %w", regionIds, year, classifyDBError(err))
+	}
+	if len(averageRegionIncomes) == 0 {
+		return nil, fmt.Errorf("regions not found with region_ids %v, year [%d]: %w", regionIds, year, domain.ErrNotFound)
 	}
 
 	return averageRegionIncomes, nil
 }
 
-func (r *SQLRepository) getRegionIncomesByAllParameters(ctx context.Context, tx *sqlx.Tx, regionId int32, year int32, quarter int32) (*domain.AverageRegionIncomes, error) {
-	averageRegionIncomes := &domain.AverageRegionIncomes{}
+func (r *SQLRepository) getRegionIncomesByAllParameters(ctx context.Context, tx *sqlx.Tx, regionIds []int32, year int32, quarter int32, averaging domain.Averaging) ([]*domain.AverageRegionIncomes, error) {
+	averageRegionIncomes := make([]*domain.AverageRegionIncomes, 0, len(regionIds))
 
-	query := `SELECT 
+	query := `SELECT
 					incomes.region_id,
 					r.region_name AS region_name,
 					$3 AS quarter,
 					$2 AS year,
-					AVG(incomes.value) AS average_region_incomes
+					` + averageExpression(averaging.Method, "incomes", "$4") + ` AS average_region_incomes,
+					` + coveredPeriodColumns("incomes", "$4") + `
 				FROM (
-					SELECT *
+					SELECT
+						region_id,
+						year,
+						quarter,
+						value,
+						loaded_at,
+						ROW_NUMBER() OVER (PARTITION BY region_id ORDER BY year DESC, quarter DESC) AS rn
 					FROM (
-						SELECT DISTINCT ON (ri.year, ri.quarter)
-							ri.region_id, 
-							ri.year, 
-							ri.quarter, 
-							ri.value,
-							ri.loaded_at
-						FROM 
-							region_incomes ri
-						WHERE 
-							ri.region_id = $1 
-							AND ri.year <= $2 
-							AND NOT (ri.year = $2 AND ri.quarter >= $3)
-						ORDER BY 
-							ri.year DESC, 
-							ri.quarter DESC,
-							ri.loaded_at DESC
+						SELECT DISTINCT ON (region_id, year, quarter)
+							region_id,
+							year,
+							quarter,
+							value,
+							loaded_at
+						FROM region_incomes
+						WHERE ` + regionIdsFilter + `
+							AND year <= $2
+							AND NOT (year = $2 AND quarter >= $3)
+						ORDER BY region_id, year DESC, quarter DESC, downloaded_at DESC, loaded_at DESC
 					) AS latest_quarters
-					ORDER BY year DESC, quarter DESC
-					LIMIT 4
 				) AS incomes
 				JOIN regions r ON incomes.region_id = r.region_id
-				GROUP BY 
-					incomes.region_id, 
-					r.region_name`
+				WHERE incomes.rn <= $4::int
+				  AND incomes.value IS NOT NULL
+				GROUP BY
+					incomes.region_id,
+					r.region_name
+				ORDER BY incomes.region_id`
 
-	err := tx.GetContext(ctx, averageRegionIncomes, query, regionId, year, quarter)
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, fmt.Errorf("region not found with region_id [%d], year [%d], quarter [%d]: %w", regionId, year, quarter, err)
+	err := tx.SelectContext(ctx, &averageRegionIncomes, query, pq.Array(regionIds), year, quarter, averaging.Window)
+	if err != nil {
+		return nil, fmt.Errorf("err getting incomes by region_ids %v, year [%d], quarter [%d]: %w", regionIds, year, quarter, classifyDBError(err))
 	}
+	if len(averageRegionIncomes) == 0 {
+		return nil, fmt.Errorf("regions not found with region_ids %v, year [%d], quarter [%d]: %w", regionIds, year, quarter, domain.ErrNotFound)
+	}
+
+	return averageRegionIncomes, nil
+}
+
+// getCalendarYearIncomes averages Q1-Q4 of year. Regions with fewer than four loaded quarters are
+// still returned, with complete set to false.
+func (r *SQLRepository) getCalendarYearIncomes(ctx context.Context, tx *sqlx.Tx, regionIds []int32, year int32, averaging domain.Averaging) ([]*domain.AverageRegionIncomes, error) {
+	averageRegionIncomes := make([]*domain.AverageRegionIncomes, 0, len(regionIds))
+
+	query := `SELECT
+					r.region_name AS region_name,
+					ri.region_id AS region_id,
+					$2 AS year,
+					0 AS quarter,
+					` + averageExpression(averaging.Method, "ri", "$3") + ` AS average_region_incomes,
+					` + coveredPeriodColumns("ri", "$3") + `
+				FROM (
+					SELECT
+						region_id,
+						year,
+						quarter,
+						value,
+						loaded_at,
+						ROW_NUMBER() OVER (PARTITION BY region_id ORDER BY year DESC, quarter DESC) AS rn
+					FROM (
+						SELECT DISTINCT ON (region_id, year, quarter)
+							region_id,
+							year,
+							quarter,
+							value,
+							loaded_at
+						FROM region_incomes
+						WHERE ` + regionIdsFilter + `
+						  AND year = $2
+						ORDER BY region_id, year DESC, quarter DESC, downloaded_at DESC, loaded_at DESC
+					) AS latest_quarters
+				) AS ri
+				JOIN regions r ON ri.region_id = r.region_id
+				WHERE ri.value IS NOT NULL
+				GROUP BY r.region_name, ri.region_id
+				ORDER BY ri.region_id`
+
+	err := tx.SelectContext(ctx, &averageRegionIncomes, query, pq.Array(regionIds), year, averaging.ExpectedQuarters())
 	if err != nil {
-		return nil, fmt.Errorf("err getting incomes by region_id [%d], year [%d], quarter [%d]: %w", regionId, year, quarter, err)
+		return nil, fmt.Errorf("err getting calendar year incomes by region_ids %v, year [%d]: %w", regionIds, year, classifyDBError(err))
+	}
+	if len(averageRegionIncomes) == 0 {
+		return nil, fmt.Errorf("regions not found with region_ids %v, calendar year [%d]: %w", regionIds, year, domain.ErrNotFound)
 	}
 
 	return averageRegionIncomes, nil
 }
 
-func (r *RedisRepository) GetCachedRegionIncomes(ctx context.Context, regionId int32, year int32, quarter int32) (*domain.AverageRegionIncomes, error) {
-	averageRegionIncomes := &domain.AverageRegionIncomes{}
+// GetCachedRegionIncomes reads cached averages in a single round trip. A request for all
+// regions is cached under one key and reports redis.Nil on a miss; a request for explicit
+// regions uses MGET and returns only the entries that were found.
+func (r *RedisRepository) GetCachedRegionIncomes(ctx context.Context, regionIds []int32, year int32, quarter int32, averaging domain.Averaging) ([]*domain.AverageRegionIncomes, error) {
+	if len(regionIds) == 0 {
+		var averageRegionIncomesJSON string
 
-	redisKey := createCachedKey(regionId, year, quarter)
+		err := r.db.Get(ctx, createCachedAllKey(year, quarter, averaging)).Scan(&averageRegionIncomesJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error getting cached region incomes: %w", classifyRedisError(err))
+		}
 
-	var averageRegionIncomesJSON string
+		averageRegionIncomes := make([]*domain.AverageRegionIncomes, 0)
+		err = json.Unmarshal([]byte(averageRegionIncomesJSON), &averageRegionIncomes)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling cached region incomes: %w", err)
+		}
+		r.logger.Info("get cached region incomes", slog.String("region_id", "all"), slog.String("year", fmt.Sprintf("%d", year)), slog.String("quarter", fmt.Sprintf("%d", quarter)))
+		return averageRegionIncomes, nil
+	}
 
-	err := r.db.Get(ctx, redisKey).Scan(&averageRegionIncomesJSON)
-	if err != nil {
-		return nil, fmt.Errorf("error getting cached region incomes: %w", err)
+	redisKeys := make([]string, 0, len(regionIds))
+	for _, regionId := range regionIds {
+		redisKeys = append(redisKeys, createCachedKey(regionId, year, quarter, averaging))
 	}
 
-	err = json.Unmarshal([]byte(averageRegionIncomesJSON), averageRegionIncomes)
+	values, err := r.db.MGet(ctx, redisKeys...).Result()
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshalling cached region incomes: %w", err)
+		return nil, fmt.Errorf("error getting cached region incomes: %w", classifyRedisError(err))
+	}
+
+	averageRegionIncomes := make([]*domain.AverageRegionIncomes, 0, len(values))
+	for _, value := range values {
+		averageRegionIncomesJSON, ok := value.(string)
+		if !ok {
+			continue
+		}
+		averageRegionIncome := &domain.AverageRegionIncomes{}
+		err = json.Unmarshal([]byte(averageRegionIncomesJSON), averageRegionIncome)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling cached region incomes: %w", err)
+		}
+		averageRegionIncomes = append(averageRegionIncomes, averageRegionIncome)
 	}
-	r.logger.Info("get cached region incomes", slog.String("region_id", fmt.Sprintf("%d", regionId)), slog.String("year", fmt.Sprintf("%d", year)), slog.String("quarter", fmt.Sprintf("%d", quarter)))
+	r.logger.Info("get cached region incomes", slog.String("region_id", fmt.Sprintf("%v", regionIds)), slog.String("year", fmt.Sprintf("%d", year)), slog.String("quarter", fmt.Sprintf("%d", quarter)), slog.Int("found", len(averageRegionIncomes)))
 	return averageRegionIncomes, nil
 }
 
+// SetCachedRegionIncomes stores averages in a single pipelined round trip: one key for
+// the all-regions request, or one key per region otherwise.
 func (r *RedisRepository) SetCachedRegionIncomes(
 	ctx context.Context,
-	averageRegionIncomes *domain.AverageRegionIncomes,
-	regionId int32,
+	averageRegionIncomes []*domain.AverageRegionIncomes,
+	regionIds []int32,
 	year int32,
-	quarter int32) error {
+	quarter int32,
+	averaging domain.Averaging) error {
 
-	redisKey := createCachedKey(regionId, year, quarter)
+	if len(regionIds) == 0 {
+		averageRegionIncomesJSON, err := json.Marshal(averageRegionIncomes)
+		if err != nil {
+			return fmt.Errorf("error marshalling cached region incomes: %w", err)
+		}
 
-	averageRegionIncomesJSON, err := json.Marshal(averageRegionIncomes)
-	if err != nil {
-		return fmt.Errorf("error marshalling cached region incomes: %w", err)
+		err = r.db.Set(ctx, createCachedAllKey(year, quarter, averaging), averageRegionIncomesJSON, r.ttl).Err()
+		if err != nil {
+			return fmt.Errorf("error setting cached region incomes: %w", classifyRedisError(err))
+		}
+		r.logger.Info("set cached region incomes", slog.String("region_id", "all"), slog.String("year", fmt.Sprintf("%d", year)), slog.String("quarter", fmt.Sprintf("%d", quarter)))
+		return nil
 	}
 
-	err = r.db.Set(ctx, redisKey, averageRegionIncomesJSON, r.ttl).Err()
+	_, err := r.db.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, averageRegionIncome := range averageRegionIncomes {
+			averageRegionIncomeJSON, err := json.Marshal(averageRegionIncome)
+			if err != nil {
+				return fmt.Errorf("error marshalling cached region incomes: %w", err)
+			}
+			pipe.Set(ctx, createCachedKey(averageRegionIncome.RegionId, year, quarter, averaging), averageRegionIncomeJSON, r.ttl)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("error setting cached region incomes: %w", err)
+		return fmt.Errorf("error setting cached region incomes: %w", classifyRedisError(err))
 	}
-	r.logger.Info("set cached region incomes", slog.String("region_id", fmt.Sprintf("%d", regionId)), slog.String("year", fmt.Sprintf("%d", year)), slog.String("quarter", fmt.Sprintf("%d", quarter)))
+	r.logger.Info("set cached region incomes", slog.String("region_id", fmt.Sprintf("%v", regionIds)), slog.String("year", fmt.Sprintf("%d", year)), slog.String("quarter", fmt.Sprintf("%d", quarter)))
 	return nil
 }
 
-func createCachedKey(regionId int32, year int32, quarter int32) string {
-	return fmt.Sprintf("region_incomes_%d_%d_%d", regionId, year, quarter)
+func createCachedKey(regionId int32, year int32, quarter int32, averaging domain.Averaging) string {
+	return fmt.Sprintf("region_incomes_%d_%d_%d_%s_%d_%s", regionId, year, quarter, averaging.Mode, averaging.Window, averaging.Method)
+}
+
+func createCachedAllKey(year int32, quarter int32, averaging domain.Averaging) string {
+	return fmt.Sprintf("region_incomes_all_%d_%d_%s_%d_%s", year, quarter, averaging.Mode, averaging.Window, averaging.Method)
 }
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding/charmap"
+)
+
+var utf8BOM = []byte("\xEF\xBB\xBF")
+
+// csvFormat reads a CSV file into the single sheet of a new workbook, keeping every cell as text. Files that are not
+// valid UTF-8 are read as Windows-1251, the usual encoding of Russian CSV exports.
+type csvFormat struct {
+	// headerRows are the 1-based rows the delimiter is chosen on.
+	headerRows []int
+}
+
+func (csvFormat) Name() string { return "csv" }
+
+// Sniff takes any text file whatever its extension, except markup: an HTML error page or an XML
+// message saved in place of the table is left unrecognized.
+func (csvFormat) Sniff(filePath string, head []byte) bool {
+	if isMarkup(head) {
+		return false
+	}
+	return hasExtension(filePath, ".csv", ".txt") || (len(head) > 0 && !bytes.ContainsRune(head, 0))
+}
+
+func isMarkup(head []byte) bool {
+	text := bytes.TrimLeft(bytes.TrimPrefix(head, utf8BOM), " \t\r\n")
+	if bytes.HasPrefix(text, []byte("<")) {
+		return true
+	}
+	lower := bytes.ToLower(text)
+	return bytes.Contains(lower, []byte("<html")) || bytes.Contains(lower, []byte("<?xml"))
+}
+
+func (f csvFormat) Open(filePath string) (*excelize.File, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	data = bytes.TrimPrefix(data, utf8BOM)
+	if !utf8.Valid(data) {
+		data, err = charmap.Windows1251.NewDecoder().Bytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode file as Windows-1251: %w", err)
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = csvDelimiter(data, f.headerRows)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	file := excelize.NewFile()
+	// The reader skips empty lines; they still count as rows, so that the layout's row numbers
+	// mean the same as in a workbook.
+	rowNumber, nextLine := 0, 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		rowNumber += line - nextLine + 1
+		nextLine = line + 1 + strings.Count(strings.Join(record, ""), "\n")
+
+		cell, err := excelize.CoordinatesToCellName(1, rowNumber)
+		if err != nil {
+			return nil, err
+		}
+		if err := file.SetSheetRow(defaultSheetName, cell, &record); err != nil {
+			return nil, fmt.Errorf("failed to load csv line %d: %w", line, err)
+		}
+	}
+	return file, nil
+}
+
+// csvDelimiters are tried in order: a semicolon, which files with decimal commas use, then a tab
+// and a comma.
+var csvDelimiters = []rune{';', '\t', ','}
+
+// csvDelimiter picks the first delimiter that splits every header row into the same number of
+// fields, more than one. Titles and notes are left out, as a semicolon in a footnote says nothing
+// of the delimiter. A file none of them splits is read with a comma.
+func csvDelimiter(data []byte, headerRows []int) rune {
+	lines := strings.Split(string(data), "\n")
+	for _, delimiter := range csvDelimiters {
+		fields := 0
+		for _, row := range headerRows {
+			count := 0
+			if row >= 1 && row <= len(lines) {
+				count = csvFieldCount(strings.TrimSuffix(lines[row-1], "\r"), delimiter)
+			}
+			if count < 2 || (fields != 0 && count != fields) {
+				fields = -1
+				break
+			}
+			fields = count
+		}
+		if fields > 1 {
+			return delimiter
+		}
+	}
+	return ','
+}
+
+// csvFieldCount counts the fields of a line, minding quoted delimiters.
+func csvFieldCount(line string, delimiter rune) int {
+	reader := csv.NewReader(strings.NewReader(line))
+	reader.Comma = delimiter
+	reader.LazyQuotes = true
+	record, err := reader.Read()
+	if err != nil {
+		return 0
+	}
+	return len(record)
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+// classifyDBError marks connection-level failures as domain.ErrUpstreamUnavailable so callers can
+// tell an unreachable database from a failing query.
+func classifyDBError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// 08: connection exception, 57P: operator intervention (shutdown, cannot connect now).
+		if pqErr.Code.Class() == "08" || strings.HasPrefix(string(pqErr.Code), "57P") {
+			return fmt.Errorf("%w: %w", domain.ErrUpstreamUnavailable, err)
+		}
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.As(err, &netErr) {
+		return fmt.Errorf("%w: %w", domain.ErrUpstreamUnavailable, err)
+	}
+
+	return err
+}
+
+// classifyRedisError marks every cache failure except a cache miss as domain.ErrUpstreamUnavailable.
+func classifyRedisError(err error) error {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", domain.ErrUpstreamUnavailable, err)
+}
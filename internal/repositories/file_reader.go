@@ -1,10 +1,15 @@
 package repositories
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
 	"github.com/donskova1ex/AverageRegionIncomes/tools"
+	"io"
 	"log/slog"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -17,88 +22,101 @@ type ExcelReader struct {
 	logger     *slog.Logger
 	maxRetries int
 	retryDelay time.Duration
+	layout     *domain.SheetLayout
+	formats    SourceFormats
 }
 
-func NewExcelReader(logger *slog.Logger, maxRetries int, retryDelay time.Duration) *ExcelReader {
+func NewExcelReader(logger *slog.Logger, maxRetries int, retryDelay time.Duration, layout *domain.SheetLayout) *ExcelReader {
 	return &ExcelReader{
 		logger:     logger,
 		maxRetries: maxRetries,
 		retryDelay: retryDelay,
+		layout:     layout,
+		formats:    DefaultSourceFormats(layout),
 	}
 }
 
-func (r *ExcelReader) openFileWithRetry(filePath string) (*excelize.File, error) {
+// openFileWithRetry opens the file in its format, retrying on failure unless the format cannot
+// read the file at all.
+func (r *ExcelReader) openFileWithRetry(format SourceFormat, filePath string) (*excelize.File, error) {
 	var file *excelize.File
 	var err error
 	for attempt := 1; attempt <= r.maxRetries; attempt++ {
-		file, err = excelize.OpenFile(filePath)
+		file, err = format.Open(filePath)
 		if err == nil {
 			return file, nil
 		}
-		r.logger.Error(
-			"err",
-			"failed to get file, retrying...",
-			"attemp", fmt.Sprintf(": #%d of #%d", attempt, r.maxRetries),
-			err.Error(),
-		)
-		time.Sleep(r.retryDelay)
-	}
-	return nil, err
-}
-
-func (r *ExcelReader) getRowsWithRetry(file *excelize.File) ([][]string, error) {
-	var rows [][]string
-	var err error
-
-	for attempt := 1; attempt <= r.maxRetries; attempt++ {
-		rows, err = tools.FormattingFileRows(file)
-		if err == nil {
-			return rows, nil
+		if errors.Is(err, errUnsupportedFormat) {
+			return nil, err
 		}
-		r.logger.Error(
-			"err",
-			"failed to get file, retrying...",
-			"attemp", fmt.Sprintf(": #%d of #%d", attempt, r.maxRetries),
-			err.Error(),
-		)
+		r.logger.Error("failed to get file, retrying...", "attempt", attempt, "err", err)
 		time.Sleep(r.retryDelay)
 	}
 	return nil, err
 }
 
-func (r *ExcelReader) processRows(rows [][]string) ([]*domain.ExcelRegionIncome, error) {
-	var allRegionIncomes []*domain.ExcelRegionIncome
-	var mu sync.Mutex
+// processRows parses the rows concurrently and returns the values and rejects in row order. A row
+// that fails to parse is returned as a reject with the reason instead of being dropped.
+func (r *ExcelReader) processRows(rows []*domain.SourceRow) ([]*domain.ExcelRegionIncome, []*domain.IngestionReject) {
+	rowIncomes := make([][]*domain.ExcelRegionIncome, len(rows))
+	rowErrors := make([]error, len(rows))
 	var wg sync.WaitGroup
 
-	wg.Add(len(rows) - 1)
-	for i, row := range rows[1:] {
-		go func(i int, row []string) {
+	wg.Add(len(rows))
+	for idx, row := range rows {
+		go func(idx int, row *domain.SourceRow) {
 			defer wg.Done()
-			region := row[0]
-			regionIncomes, err := r.convertRowToIncomes(rows[0][1:], row[1:], region)
-			if err != nil {
-				r.logger.Error("failed to convert row",
-					"row", i+1,
-					"error", err)
-				return
-			}
-			mu.Lock()
-			allRegionIncomes = append(allRegionIncomes, regionIncomes...)
-			mu.Unlock()
-		}(i, row)
+			rowIncomes[idx], rowErrors[idx] = r.ParseRow(row)
+		}(idx, row)
 	}
 	wg.Wait()
 
-	return allRegionIncomes, nil
+	var allRegionIncomes []*domain.ExcelRegionIncome
+	var rejects []*domain.IngestionReject
+	for idx, row := range rows {
+		if err := rowErrors[idx]; err != nil {
+			r.logger.Error("failed to convert row",
+				"sheet", row.Sheet,
+				"row", row.RowNumber,
+				"error", err)
+			rejects = append(rejects, &domain.IngestionReject{Row: row, Reason: err.Error()})
+			continue
+		}
+		allRegionIncomes = append(allRegionIncomes, rowIncomes[idx]...)
+	}
+
+	return allRegionIncomes, rejects
 }
 
-func (r *ExcelReader) convertRowToIncomes(dataParts []string, valueParts []string, region string) ([]*domain.ExcelRegionIncome, error) {
+// ParseRow converts a source row into one value per period of its header.
+func (r *ExcelReader) ParseRow(row *domain.SourceRow) ([]*domain.ExcelRegionIncome, error) {
+	return parseSourceRow(row, r.layout.NumberLocale)
+}
+
+// parseSourceRow converts a row whose first cell is the region and whose header names the
+// period of each following cell, "YYYY.Q" for a quarter and "YYYY" for the annual total.
+func parseSourceRow(row *domain.SourceRow, locale domain.NumberLocale) ([]*domain.ExcelRegionIncome, error) {
+	if len(row.Cells) == 0 || len(row.Header) == 0 {
+		return nil, fmt.Errorf("empty row")
+	}
+	regionIncomes, err := convertRowToIncomes(row.Header[1:], row.Cells[1:], row.Cells[0], locale)
+	if err != nil {
+		return nil, err
+	}
+	for _, regionIncome := range regionIncomes {
+		regionIncome.Sheet = row.Sheet
+		regionIncome.Source = row
+	}
+	return regionIncomes, nil
+}
+
+func convertRowToIncomes(dataParts []string, valueParts []string, region string, locale domain.NumberLocale) ([]*domain.ExcelRegionIncome, error) {
 	var regionIncomes []*domain.ExcelRegionIncome
 
 	for index, value := range dataParts {
+		// "YYYY.Q" is a quarter, a bare "YYYY" is the annual total of the year.
 		parts := strings.Split(value, ".")
-		if len(parts) != 2 {
+		if len(parts) > 2 {
 			return nil, fmt.Errorf("invalid date format: %s", value)
 		}
 
@@ -107,45 +125,59 @@ func (r *ExcelReader) convertRowToIncomes(dataParts []string, valueParts []strin
 			return nil, fmt.Errorf("failed to parse year: %w", err)
 		}
 
-		quarter, err := strconv.ParseInt(parts[1], 10, 32)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse quarter: %w", err)
+		periodType := domain.PeriodYear
+		var quarter int64
+		if len(parts) == 2 {
+			periodType = domain.PeriodQuarter
+			quarter, err = strconv.ParseInt(parts[1], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse quarter: %w", err)
+			}
 		}
 
-		if index >= len(valueParts) {
-			return nil, fmt.Errorf("missing value for date %s", value)
+		regionIncome := &domain.ExcelRegionIncome{
+			Region:     region,
+			PeriodType: periodType,
+			Year:       int32(year),
+			Quarter:    int32(quarter),
 		}
 
-		var strIncome string
-		switch {
-		case strings.Contains(valueParts[index], ","):
-			strIncome = strings.ReplaceAll(valueParts[index], ",", "")
-		case valueParts[index] == "":
-			strIncome = "0"
-		default:
-			strIncome = valueParts[index]
+		// excelize does not return trailing empty cells, so a value past the end of the row is empty.
+		var cell string
+		if index < len(valueParts) {
+			cell = valueParts[index]
+		}
+		if domain.IsMissingValue(cell) {
+			regionIncome.Missing = true
+			regionIncome.MissingMarker = strings.TrimSpace(cell)
+			regionIncomes = append(regionIncomes, regionIncome)
+			continue
 		}
 
-		income, err := strconv.ParseFloat(strIncome, 64)
+		income, err := locale.ParseNumber(cell)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse income: %w, [%s]", err, region)
 		}
 
-		regionIncomes = append(regionIncomes, &domain.ExcelRegionIncome{
-			Region:               region,
-			Year:                 int32(year),
-			Quarter:              int32(quarter),
-			AverageRegionIncomes: float32(income),
-		})
+		regionIncome.AverageRegionIncomes = income
+		regionIncomes = append(regionIncomes, regionIncome)
 	}
 
 	return regionIncomes, nil
 }
 
-func (r *ExcelReader) ReadFile(filepath string) ([]*domain.ExcelRegionIncome, error) {
-	file, err := r.openFileWithRetry(filepath)
+// ReadFile reads an XLSX, ODS or CSV source file, detecting its format from its contents. The
+// format is only detected here, so a file of no known format, such as an error page saved in
+// place of the workbook, fails the read and with it the ingestion run that records it.
+func (r *ExcelReader) ReadFile(filepath string) (*domain.ParsedFile, error) {
+	format, err := r.formats.Detect(filepath)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Info("source file format detected", "format", format.Name())
+	file, err := r.openFileWithRetry(format, filepath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, fmt.Errorf("failed to open %s file: %w", format.Name(), err)
 	}
 	defer file.Close()
 
@@ -154,14 +186,44 @@ func (r *ExcelReader) ReadFile(filepath string) ([]*domain.ExcelRegionIncome, er
 		return nil, fmt.Errorf("no sheets found in file")
 	}
 
-	rows, err := r.getRowsWithRetry(file)
+	// Only opening the file is retried: parsing reads the same bytes and would fail the same way.
+	rows, err := tools.FormattingFileRows(file, r.layout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get rows: %w", err)
 	}
 
-	if len(rows) < 2 {
+	if len(rows) < 1 {
 		return nil, fmt.Errorf("file contains insufficient data")
 	}
+	for _, row := range rows {
+		row.File = filepath
+	}
+
+	incomes, rejects := r.processRows(rows)
+	return &domain.ParsedFile{
+		Path:       filepath,
+		SheetCount: len(sheets),
+		RowCount:   len(rows),
+		Incomes:    incomes,
+		Rejects:    rejects,
+	}, nil
+}
+
+// FileSHA256 returns the hex encoded SHA-256 of the file contents.
+func (r *ExcelReader) FileSHA256(filePath string) (string, error) {
+	return fileSHA256(filePath)
+}
 
-	return r.processRows(rows)
+func fileSHA256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to hash file: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
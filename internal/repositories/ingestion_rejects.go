@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type ingestionRejectRow struct {
+	ID         int64          `db:"id"`
+	SourceFile string         `db:"source_file"`
+	Sheet      string         `db:"sheet"`
+	RowNumber  int            `db:"row_number"`
+	Header     pq.StringArray `db:"header"`
+	Cells      pq.StringArray `db:"cells"`
+	Reason     string         `db:"reason"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+	ResolvedAt *time.Time     `db:"resolved_at"`
+}
+
+func (row *ingestionRejectRow) toDomain() *domain.IngestionReject {
+	return &domain.IngestionReject{
+		ID: row.ID,
+		Row: &domain.SourceRow{
+			File:      row.SourceFile,
+			Sheet:     row.Sheet,
+			RowNumber: row.RowNumber,
+			Header:    row.Header,
+			Cells:     row.Cells,
+		},
+		Reason:     row.Reason,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+		ResolvedAt: row.ResolvedAt,
+	}
+}
+
+// GetIngestionRejects returns quarantined rows with the given status, oldest first. A limit of 0
+// returns all of them.
+func (r *SQLRepository) GetIngestionRejects(ctx context.Context, status domain.IngestionRejectStatus, limit int32) ([]*domain.IngestionReject, error) {
+	rows := make([]*ingestionRejectRow, 0)
+
+	query := `SELECT id, source_file, sheet, row_number, header, cells, reason, created_at, updated_at, resolved_at
+				FROM ingestion_rejects
+				WHERE $1 = 'all'
+					OR ($1 = 'open' AND resolved_at IS NULL)
+					OR ($1 = 'resolved' AND resolved_at IS NOT NULL)
+				ORDER BY id
+				LIMIT NULLIF($2, 0)`
+
+	err := r.db.SelectContext(ctx, &rows, query, string(status), limit)
+	if err != nil {
+		return nil, fmt.Errorf("err getting ingestion rejects with status [%s]: %w", status, classifyDBError(err))
+	}
+
+	rejects := make([]*domain.IngestionReject, 0, len(rows))
+	for _, row := range rows {
+		rejects = append(rejects, row.toDomain())
+	}
+	return rejects, nil
+}
+
+// CreateIngestionRejects puts rows into quarantine. A row already quarantined gets the new
+// reason and cells and is reopened if it had been resolved.
+func (r *SQLRepository) CreateIngestionRejects(ctx context.Context, rejects []*domain.IngestionReject) error {
+	if err := upsertIngestionRejects(ctx, r.db, rejects); err != nil {
+		return fmt.Errorf("error creating ingestion rejects: %w", classifyDBError(err))
+	}
+	return nil
+}
+
+func upsertIngestionRejects(ctx context.Context, db sqlx.ExtContext, rejects []*domain.IngestionReject) error {
+	if len(rejects) == 0 {
+		return nil
+	}
+
+	rows := make([]*ingestionRejectRow, 0, len(rejects))
+	seen := make(map[string]bool, len(rejects))
+	for _, reject := range rejects {
+		key := fmt.Sprintf("%s\x00%s\x00%d", reject.Row.File, reject.Row.Sheet, reject.Row.RowNumber)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		rows = append(rows, &ingestionRejectRow{
+			SourceFile: reject.Row.File,
+			Sheet:      reject.Row.Sheet,
+			RowNumber:  reject.Row.RowNumber,
+			Header:     reject.Row.Header,
+			Cells:      reject.Row.Cells,
+			Reason:     reject.Reason,
+		})
+	}
+
+	query := `
+		INSERT INTO ingestion_rejects (source_file, sheet, row_number, header, cells, reason)
+		VALUES (:source_file, :sheet, :row_number, :header, :cells, :reason)
+		ON CONFLICT (source_file, sheet, row_number) DO UPDATE
+		SET header = EXCLUDED.header,
+			cells = EXCLUDED.cells,
+			reason = EXCLUDED.reason,
+			updated_at = NOW(),
+			resolved_at = NULL`
+
+	_, err := sqlx.NamedExecContext(ctx, db, query, rows)
+	return err
+}
+
+// resolveIngestionRejects marks the quarantined copies of rows that have now been loaded as resolved.
+func resolveIngestionRejects(ctx context.Context, tx *sqlx.Tx, loadedRows []*domain.SourceRow) (int64, error) {
+	if len(loadedRows) == 0 {
+		return 0, nil
+	}
+
+	files := make([]string, 0, len(loadedRows))
+	sheets := make([]string, 0, len(loadedRows))
+	rowNumbers := make([]int64, 0, len(loadedRows))
+	for _, row := range loadedRows {
+		files = append(files, row.File)
+		sheets = append(sheets, row.Sheet)
+		rowNumbers = append(rowNumbers, int64(row.RowNumber))
+	}
+
+	query := `UPDATE ingestion_rejects AS rejects
+				SET resolved_at = NOW(), updated_at = NOW()
+				FROM UNNEST($1::text[], $2::text[], $3::int[]) AS loaded (source_file, sheet, row_number)
+				WHERE rejects.source_file = loaded.source_file
+					AND rejects.sheet = loaded.sheet
+					AND rejects.row_number = loaded.row_number
+					AND rejects.resolved_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, query, pq.Array(files), pq.Array(sheets), pq.Array(rowNumbers))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
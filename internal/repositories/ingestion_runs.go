@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/lib/pq"
+)
+
+type ingestionRunRow struct {
+	ID                 int64          `db:"id"`
+	StartedAt          time.Time      `db:"started_at"`
+	FinishedAt         *time.Time     `db:"finished_at"`
+	SourceURL          string         `db:"source_url"`
+	LocalPath          string         `db:"local_path"`
+	ETag               string         `db:"etag"`
+	LastModified       string         `db:"last_modified"`
+	FileSHA256         string         `db:"file_sha256"`
+	SheetCount         int            `db:"sheet_count"`
+	RowsParsed         int            `db:"rows_parsed"`
+	RowsInserted       int64          `db:"rows_inserted"`
+	AnnualRowsInserted int64          `db:"annual_rows_inserted"`
+	RowsRejected       int            `db:"rows_rejected"`
+	UnmatchedRegions   pq.StringArray `db:"unmatched_regions"`
+	Status             string         `db:"status"`
+	Error              string         `db:"error"`
+	SkipReason         string         `db:"skip_reason"`
+}
+
+const ingestionRunColumns = `id, started_at, finished_at, source_url, local_path, etag, last_modified,
+					file_sha256, sheet_count, rows_parsed, rows_inserted, annual_rows_inserted, rows_rejected,
+					unmatched_regions, status, error, skip_reason`
+
+func newIngestionRunRow(run *domain.IngestionRun) *ingestionRunRow {
+	unmatchedRegions := run.UnmatchedRegions
+	if unmatchedRegions == nil {
+		unmatchedRegions = []string{}
+	}
+	return &ingestionRunRow{
+		ID:                 run.ID,
+		StartedAt:          run.StartedAt,
+		FinishedAt:         run.FinishedAt,
+		SourceURL:          run.SourceURL,
+		LocalPath:          run.LocalPath,
+		ETag:               run.ETag,
+		LastModified:       run.LastModified,
+		FileSHA256:         run.FileSHA256,
+		SheetCount:         run.SheetCount,
+		RowsParsed:         run.RowsParsed,
+		RowsInserted:       run.RowsInserted,
+		AnnualRowsInserted: run.AnnualRowsInserted,
+		RowsRejected:       run.RowsRejected,
+		UnmatchedRegions:   unmatchedRegions,
+		Status:             string(run.Status),
+		Error:              run.Error,
+		SkipReason:         run.SkipReason,
+	}
+}
+
+func (row *ingestionRunRow) toDomain() *domain.IngestionRun {
+	return &domain.IngestionRun{
+		ID:                 row.ID,
+		StartedAt:          row.StartedAt,
+		FinishedAt:         row.FinishedAt,
+		SourceURL:          row.SourceURL,
+		LocalPath:          row.LocalPath,
+		ETag:               row.ETag,
+		LastModified:       row.LastModified,
+		FileSHA256:         row.FileSHA256,
+		SheetCount:         row.SheetCount,
+		RowsParsed:         row.RowsParsed,
+		RowsInserted:       row.RowsInserted,
+		AnnualRowsInserted: row.AnnualRowsInserted,
+		RowsRejected:       row.RowsRejected,
+		UnmatchedRegions:   row.UnmatchedRegions,
+		Status:             domain.IngestionRunStatus(row.Status),
+		Error:              row.Error,
+		SkipReason:         row.SkipReason,
+	}
+}
+
+// GetIngestionRuns returns the most recent ingestion runs, newest first.
+func (r *SQLRepository) GetIngestionRuns(ctx context.Context, limit int32) ([]*domain.IngestionRun, error) {
+	rows := make([]*ingestionRunRow, 0)
+
+	query := `SELECT ` + ingestionRunColumns + `
+				FROM ingestion_runs
+				ORDER BY started_at DESC, id DESC
+				LIMIT $1`
+
+	err := r.db.SelectContext(ctx, &rows, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("err getting ingestion runs: %w", classifyDBError(err))
+	}
+
+	runs := make([]*domain.IngestionRun, 0, len(rows))
+	for _, row := range rows {
+		runs = append(runs, row.toDomain())
+	}
+	return runs, nil
+}
+
+// GetLastSuccessfulIngestionRun returns the newest run of the source that succeeded or found the
+// source unchanged, that is the run whose file is the one currently loaded from that source.
+func (r *SQLRepository) GetLastSuccessfulIngestionRun(ctx context.Context, sourceURL string) (*domain.IngestionRun, error) {
+	var row ingestionRunRow
+
+	query := `SELECT ` + ingestionRunColumns + `
+				FROM ingestion_runs
+				WHERE status IN ('succeeded', 'skipped')
+					AND source_url = $1
+				ORDER BY started_at DESC, id DESC
+				LIMIT 1`
+
+	err := r.db.GetContext(ctx, &row, query, sourceURL)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("successful ingestion run not found: %w", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("err getting last successful ingestion run: %w", classifyDBError(err))
+	}
+	return row.toDomain(), nil
+}
+
+// CreateIngestionRun records the start of a run and returns its id.
+func (r *SQLRepository) CreateIngestionRun(ctx context.Context, run *domain.IngestionRun) (int64, error) {
+	query := `INSERT INTO ingestion_runs (started_at, source_url, local_path, etag, last_modified, status)
+				VALUES (:started_at, :source_url, :local_path, :etag, :last_modified, :status)
+				RETURNING id`
+
+	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("error preparing ingestion run insert: %w", classifyDBError(err))
+	}
+	defer stmt.Close()
+
+	var id int64
+	if err := stmt.GetContext(ctx, &id, newIngestionRunRow(run)); err != nil {
+		return 0, fmt.Errorf("error creating ingestion run: %w", classifyDBError(err))
+	}
+	return id, nil
+}
+
+// FinishIngestionRun stores the outcome of a run created by CreateIngestionRun.
+func (r *SQLRepository) FinishIngestionRun(ctx context.Context, run *domain.IngestionRun) error {
+	query := `UPDATE ingestion_runs
+				SET finished_at = :finished_at,
+					file_sha256 = :file_sha256,
+					sheet_count = :sheet_count,
+					rows_parsed = :rows_parsed,
+					rows_inserted = :rows_inserted,
+					annual_rows_inserted = :annual_rows_inserted,
+					rows_rejected = :rows_rejected,
+					unmatched_regions = :unmatched_regions,
+					status = :status,
+					error = :error,
+					skip_reason = :skip_reason
+				WHERE id = :id`
+
+	result, err := r.db.NamedExecContext(ctx, query, newIngestionRunRow(run))
+	if err != nil {
+		return fmt.Errorf("error finishing ingestion run [%d]: %w", run.ID, classifyDBError(err))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("ingestion run not found with id [%d]: %w", run.ID, domain.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+// odsFormat reads the tables of an OpenDocument spreadsheet into sheets of the same names.
+// Numeric cells keep their stored value, other cells their text.
+type odsFormat struct{}
+
+func (odsFormat) Name() string { return "ods" }
+
+// Sniff looks for the uncompressed mimetype entry an ODS archive starts with.
+func (odsFormat) Sniff(filePath string, head []byte) bool {
+	if len(head) == 0 {
+		return hasExtension(filePath, ".ods")
+	}
+	return bytes.HasPrefix(head, zipMagic) && bytes.Contains(head, []byte(odsMimeType))
+}
+
+func (odsFormat) Open(filePath string) (*excelize.File, error) {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ods archive: %w", err)
+	}
+	defer archive.Close()
+
+	for _, entry := range archive.File {
+		if entry.Name != "content.xml" {
+			continue
+		}
+		content, err := entry.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open ods content: %w", err)
+		}
+		defer content.Close()
+
+		file := excelize.NewFile()
+		if err := loadODSContent(content, file); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to read ods content: %w", err)
+		}
+		return file, nil
+	}
+	return nil, fmt.Errorf("ods archive has no content.xml")
+}
+
+// loadODSContent streams the tables of content.xml into the workbook. Repeated rows and cells
+// are expanded, except for the empty ones ODS uses to pad a table up to the application's
+// limits: those only advance the position.
+func loadODSContent(content io.Reader, file *excelize.File) error {
+	decoder := xml.NewDecoder(content)
+
+	var (
+		sheet         string
+		sheetCount    int
+		rowNumber     int
+		rowRepeat     int
+		row           []string
+		emptyCells    int
+		inCell        bool
+		inAnnotation  bool
+		inParagraph   bool
+		paragraphs    int
+		cellRepeat    int
+		cellValue     string
+		cellIsNumeric bool
+		cellText      strings.Builder
+	)
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "table":
+				sheet = xmlAttr(element, "name")
+				sheetCount++
+				if sheetCount == 1 {
+					err = file.SetSheetName(defaultSheetName, sheet)
+				} else {
+					_, err = file.NewSheet(sheet)
+				}
+				if err != nil {
+					return fmt.Errorf("failed to add sheet [%s]: %w", sheet, err)
+				}
+				rowNumber = 0
+			case "table-row":
+				row = nil
+				emptyCells = 0
+				if rowRepeat, err = xmlRepeatAttr(element, "number-rows-repeated"); err != nil {
+					return err
+				}
+			case "table-cell", "covered-table-cell":
+				inCell = true
+				paragraphs = 0
+				cellText.Reset()
+				if cellRepeat, err = xmlRepeatAttr(element, "number-columns-repeated"); err != nil {
+					return err
+				}
+				switch xmlAttr(element, "value-type") {
+				case "float", "percentage", "currency":
+					cellValue, cellIsNumeric = xmlAttr(element, "value"), true
+				default:
+					cellValue, cellIsNumeric = "", false
+				}
+			case "annotation":
+				// A cell comment is not part of its value.
+				inAnnotation = true
+			case "p":
+				if inCell && !inAnnotation {
+					if paragraphs > 0 {
+						cellText.WriteByte('\n')
+					}
+					paragraphs++
+					inParagraph = true
+				}
+			case "s":
+				if inParagraph {
+					spaces, err := xmlRepeatAttr(element, "c")
+					if err != nil {
+						return err
+					}
+					cellText.WriteString(strings.Repeat(" ", spaces))
+				}
+			case "tab":
+				if inParagraph {
+					cellText.WriteByte('\t')
+				}
+			case "line-break":
+				if inParagraph {
+					cellText.WriteByte('\n')
+				}
+			}
+		case xml.CharData:
+			if inParagraph {
+				cellText.Write(element)
+			}
+		case xml.EndElement:
+			switch element.Name.Local {
+			case "annotation":
+				inAnnotation = false
+			case "p":
+				inParagraph = false
+			case "table-cell", "covered-table-cell":
+				inCell = false
+				value := cellText.String()
+				if cellIsNumeric {
+					value = cellValue
+				}
+				if value == "" {
+					emptyCells += cellRepeat
+					continue
+				}
+				for ; emptyCells > 0; emptyCells-- {
+					row = append(row, "")
+				}
+				for i := 0; i < cellRepeat; i++ {
+					row = append(row, value)
+				}
+			case "table-row":
+				if len(row) == 0 {
+					rowNumber += rowRepeat
+					continue
+				}
+				for i := 0; i < rowRepeat; i++ {
+					rowNumber++
+					cell, err := excelize.CoordinatesToCellName(1, rowNumber)
+					if err != nil {
+						return err
+					}
+					if err := file.SetSheetRow(sheet, cell, &row); err != nil {
+						return fmt.Errorf("failed to load row %d of sheet [%s]: %w", rowNumber, sheet, err)
+					}
+				}
+			}
+		}
+	}
+
+	if sheetCount == 0 {
+		return fmt.Errorf("no tables found")
+	}
+	return nil
+}
+
+func xmlAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// xmlRepeatAttr reads a repeat count attribute, which defaults to 1.
+func xmlRepeatAttr(element xml.StartElement, name string) (int, error) {
+	value := xmlAttr(element, name)
+	if value == "" {
+		return 1, nil
+	}
+	repeat, err := strconv.Atoi(value)
+	if err != nil || repeat < 1 {
+		return 0, fmt.Errorf("invalid %s [%s]", name, value)
+	}
+	return repeat, nil
+}
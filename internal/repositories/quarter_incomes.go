@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+)
+
+// GetRegionQuarterIncomes returns the value of the latest download of every quarter between from and to
+// inclusive, oldest first. A zero bound leaves that side of the range open.
+func (r *SQLRepository) GetRegionQuarterIncomes(ctx context.Context, regionId int32, from domain.YearQuarter, to domain.YearQuarter) ([]*domain.RegionQuarterIncome, error) {
+	regionQuarterIncomes := make([]*domain.RegionQuarterIncome, 0)
+
+	query := `SELECT DISTINCT ON (year, quarter)
+					region_id,
+					year,
+					quarter,
+					value,
+					loaded_at
+				FROM region_incomes
+				WHERE region_id = $1
+					AND ($2 = 0 OR (year, quarter) >= ($2, $3))
+					AND ($4 = 0 OR (year, quarter) <= ($4, $5))
+				ORDER BY year, quarter, downloaded_at DESC, loaded_at DESC`
+
+	err := r.db.SelectContext(ctx, &regionQuarterIncomes, query, regionId, from.Year, from.Quarter, to.Year, to.Quarter)
+	if err != nil {
+		return nil, fmt.Errorf("err getting quarter incomes by region_id [%d], from [%s], to [%s]: %w", regionId, from, to, classifyDBError(err))
+	}
+	if len(regionQuarterIncomes) == 0 {
+		return nil, fmt.Errorf("quarter incomes not found with region_id [%d], from [%s], to [%s]: %w", regionId, from, to, domain.ErrNotFound)
+	}
+
+	return regionQuarterIncomes, nil
+}
+
+func (r *RedisRepository) GetCachedRegionQuarterIncomes(ctx context.Context, regionId int32, from domain.YearQuarter, to domain.YearQuarter) ([]*domain.RegionQuarterIncome, error) {
+	var regionQuarterIncomesJSON string
+
+	err := r.db.Get(ctx, createQuarterIncomesCachedKey(regionId, from, to)).Scan(&regionQuarterIncomesJSON)
+	if err != nil {
+		return nil, fmt.Errorf("error getting cached quarter incomes: %w", classifyRedisError(err))
+	}
+
+	regionQuarterIncomes := make([]*domain.RegionQuarterIncome, 0)
+	err = json.Unmarshal([]byte(regionQuarterIncomesJSON), &regionQuarterIncomes)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling cached quarter incomes: %w", err)
+	}
+	r.logger.Info("get cached quarter incomes", slog.String("region_id", fmt.Sprintf("%d", regionId)), slog.String("from", from.String()), slog.String("to", to.String()))
+	return regionQuarterIncomes, nil
+}
+
+func (r *RedisRepository) SetCachedRegionQuarterIncomes(
+	ctx context.Context,
+	regionQuarterIncomes []*domain.RegionQuarterIncome,
+	regionId int32,
+	from domain.YearQuarter,
+	to domain.YearQuarter) error {
+
+	regionQuarterIncomesJSON, err := json.Marshal(regionQuarterIncomes)
+	if err != nil {
+		return fmt.Errorf("error marshalling cached quarter incomes: %w", err)
+	}
+
+	err = r.db.Set(ctx, createQuarterIncomesCachedKey(regionId, from, to), regionQuarterIncomesJSON, r.ttl).Err()
+	if err != nil {
+		return fmt.Errorf("error setting cached quarter incomes: %w", classifyRedisError(err))
+	}
+	r.logger.Info("set cached quarter incomes", slog.String("region_id", fmt.Sprintf("%d", regionId)), slog.String("from", from.String()), slog.String("to", to.String()))
+	return nil
+}
+
+func createQuarterIncomesCachedKey(regionId int32, from domain.YearQuarter, to domain.YearQuarter) string {
+	return fmt.Sprintf("region_quarter_incomes_%d_%s_%s", regionId, from, to)
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+)
+
+// GetRegionAliases returns all region aliases ordered by region_id and alias.
+func (r *SQLRepository) GetRegionAliases(ctx context.Context) ([]*domain.RegionAlias, error) {
+	regionAliases := make([]*domain.RegionAlias, 0)
+
+	query := `SELECT id, region_id, alias, created_at FROM region_aliases ORDER BY region_id, alias`
+
+	err := r.db.SelectContext(ctx, &regionAliases, query)
+	if err != nil {
+		return nil, fmt.Errorf("err getting region aliases: %w", classifyDBError(err))
+	}
+
+	return regionAliases, nil
+}
+
+// CreateRegionAlias adds an alias for a region. The alias is rejected when the region does not
+// exist or when, after normalization, it already resolves to a region name or another alias.
+func (r *SQLRepository) CreateRegionAlias(ctx context.Context, regionId int32, alias string) (*domain.RegionAlias, error) {
+	var txCommited bool
+
+	alias = strings.TrimSpace(alias)
+	if domain.NormalizeRegionName(alias) == "" {
+		return nil, fmt.Errorf("alias [%s] is empty after normalization: %w", alias, domain.ErrInvalidParameter)
+	}
+
+	serializableIsolation := &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+		ReadOnly:  false,
+	}
+	tx, err := r.db.BeginTxx(ctx, serializableIsolation)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", classifyDBError(err))
+	}
+	defer func() {
+		if !txCommited {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				r.logger.Error("error rolling back transaction", slog.String("err", rollbackErr.Error()))
+			}
+		}
+	}()
+
+	var regionExists bool
+	err = tx.GetContext(ctx, &regionExists, `SELECT EXISTS (SELECT 1 FROM regions WHERE region_id = $1)`, regionId)
+	if err != nil {
+		return nil, fmt.Errorf("error checking region_id [%d]: %w", regionId, classifyDBError(err))
+	}
+	if !regionExists {
+		return nil, fmt.Errorf("region not found with region_id [%d]: %w", regionId, domain.ErrNotFound)
+	}
+
+	regionsMap, err := r.getRegionsMap(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("error filling regions map: %w", err)
+	}
+	if existingID, ok := regionsMap[domain.NormalizeRegionName(alias)]; ok {
+		return nil, fmt.Errorf("alias [%s] already resolves to region_id [%d]: %w", alias, existingID, domain.ErrConflict)
+	}
+
+	regionAlias := &domain.RegionAlias{}
+	query := `INSERT INTO region_aliases (region_id, alias) VALUES ($1, $2) RETURNING id, region_id, alias, created_at`
+	err = tx.GetContext(ctx, regionAlias, query, regionId, alias)
+	if err != nil {
+		return nil, fmt.Errorf("error creating alias [%s] for region_id [%d]: %w", alias, regionId, classifyDBError(err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", classifyDBError(err))
+	}
+	txCommited = true
+
+	return regionAlias, nil
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+)
+
+const regionsCachedKey = "regions"
+
+// GetRegions returns the whole region directory ordered by region_id.
+func (r *SQLRepository) GetRegions(ctx context.Context) ([]*domain.Regions, error) {
+	regions := make([]*domain.Regions, 0)
+
+	query := `SELECT
+					id,
+					region_id,
+					region_name,
+					COALESCE(okato_code, '') AS okato_code,
+					COALESCE(oktmo_code, '') AS oktmo_code,
+					COALESCE(iso_code, '') AS iso_code
+				FROM regions
+				ORDER BY region_id`
+
+	err := r.db.SelectContext(ctx, &regions, query)
+	if err != nil {
+		return nil, fmt.Errorf("err getting regions: %w", classifyDBError(err))
+	}
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("no regions loaded: %w", domain.ErrNotFound)
+	}
+
+	return regions, nil
+}
+
+func (r *RedisRepository) GetCachedRegions(ctx context.Context) ([]*domain.Regions, error) {
+	var regionsJSON string
+
+	err := r.db.Get(ctx, regionsCachedKey).Scan(&regionsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("error getting cached regions: %w", classifyRedisError(err))
+	}
+
+	regions := make([]*domain.Regions, 0)
+	err = json.Unmarshal([]byte(regionsJSON), &regions)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling cached regions: %w", err)
+	}
+	return regions, nil
+}
+
+func (r *RedisRepository) SetCachedRegions(ctx context.Context, regions []*domain.Regions) error {
+	regionsJSON, err := json.Marshal(regions)
+	if err != nil {
+		return fmt.Errorf("error marshalling cached regions: %w", err)
+	}
+
+	err = r.db.Set(ctx, regionsCachedKey, regionsJSON, r.ttl).Err()
+	if err != nil {
+		return fmt.Errorf("error setting cached regions: %w", classifyRedisError(err))
+	}
+	return nil
+}
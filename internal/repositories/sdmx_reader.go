@@ -0,0 +1,356 @@
+package repositories
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// SDMXReader reads SDMX-ML data messages, in the generic and the structure-specific (or compact)
+// form of SDMX 2.0 and 2.1, into the same records as the workbook. Each series becomes a source
+// row: its region code, then its observations, with a header naming their periods.
+type SDMXReader struct {
+	logger *slog.Logger
+	source *domain.SDMXSource
+}
+
+func NewSDMXReader(logger *slog.Logger, source *domain.SDMXSource) *SDMXReader {
+	return &SDMXReader{
+		logger: logger,
+		source: source,
+	}
+}
+
+// sdmxSeries is a series as read from the message. Observations outside of any series, as in
+// flat messages, each make a series of their own.
+type sdmxSeries struct {
+	dataSet      int
+	key          map[string]string
+	observations []*sdmxObservation
+}
+
+type sdmxObservation struct {
+	// key holds the dimensions and attributes of the observation, including its time.
+	key   map[string]string
+	value string
+}
+
+// FileSHA256 returns the hex encoded SHA-256 of the file contents.
+func (r *SDMXReader) FileSHA256(filePath string) (string, error) {
+	return fileSHA256(filePath)
+}
+
+// ReadFile reads the series selected by the source's filter. A series whose periods cannot be
+// read, or that repeats a period of its region already read from another series, is rejected
+// as a whole.
+func (r *SDMXReader) ReadFile(filePath string) (*domain.ParsedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	series, dataSets, err := readSDMXSeries(file, r.source.TimeDimension)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sdmx message: %w", err)
+	}
+	if dataSets == 0 {
+		return nil, fmt.Errorf("no data sets found in sdmx message")
+	}
+
+	parsed := &domain.ParsedFile{
+		Path:       filePath,
+		SheetCount: dataSets,
+	}
+	// periods maps a region code and a period to the row that provided it.
+	periods := make(map[string]int)
+	for _, s := range series {
+		if !r.source.MatchesSeries(s.key) {
+			continue
+		}
+		parsed.RowCount++
+		row, err := r.seriesRow(filePath, parsed.RowCount, s)
+		var incomes []*domain.ExcelRegionIncome
+		if err == nil {
+			incomes, err = r.ParseRow(row)
+		}
+		if err == nil {
+			err = checkDuplicatePeriods(row, periods)
+		}
+		if err != nil {
+			r.logger.Error("failed to convert series",
+				"row", row.RowNumber,
+				"region", row.Cells[0],
+				"error", err)
+			parsed.Rejects = append(parsed.Rejects, &domain.IngestionReject{Row: row, Reason: err.Error()})
+			continue
+		}
+		parsed.Incomes = append(parsed.Incomes, incomes...)
+	}
+	if parsed.RowCount == 0 {
+		return nil, fmt.Errorf("no series of the message match the series filter [%s]", r.source.SeriesFilterString())
+	}
+	return parsed, nil
+}
+
+// ParseRow converts a series row built by ReadFile. Its values are written with a decimal point,
+// as SDMX requires, and its region is a code.
+func (r *SDMXReader) ParseRow(row *domain.SourceRow) ([]*domain.ExcelRegionIncome, error) {
+	if len(row.Cells) > 0 && row.Cells[0] == "" {
+		return nil, fmt.Errorf("series has no [%s] dimension", r.source.RegionDimension)
+	}
+	incomes, err := parseSourceRow(row, domain.NumberLocaleEN)
+	if err != nil {
+		return nil, err
+	}
+	for _, income := range incomes {
+		income.RegionCode = income.Region
+	}
+	return incomes, nil
+}
+
+// seriesRow lays a series out as a source row. A period that cannot be read is kept in the
+// header as written and reported as the error.
+func (r *SDMXReader) seriesRow(filePath string, rowNumber int, s *sdmxSeries) (*domain.SourceRow, error) {
+	row := &domain.SourceRow{
+		File:      filePath,
+		Sheet:     fmt.Sprintf("DataSet %d", s.dataSet),
+		RowNumber: rowNumber,
+		Header:    []string{""},
+		Cells:     []string{s.key[r.source.RegionDimension]},
+	}
+
+	var periodErr error
+	for _, observation := range s.observations {
+		timePeriod := observation.key[r.source.TimeDimension]
+		var periodLabel string
+		if r.source.PeriodDimension != "" {
+			periodLabel = observation.key[r.source.PeriodDimension]
+			if periodLabel == "" {
+				periodLabel = s.key[r.source.PeriodDimension]
+			}
+		}
+		period, err := sdmxPeriod(timePeriod, periodLabel)
+		if err != nil {
+			period = strings.TrimSpace(timePeriod + " " + periodLabel)
+			if periodErr == nil {
+				periodErr = err
+			}
+		}
+
+		// NaN is how SDMX marks an observation without a value.
+		value := strings.TrimSpace(observation.value)
+		if strings.EqualFold(value, "NaN") {
+			value = ""
+		}
+		row.Header = append(row.Header, period)
+		row.Cells = append(row.Cells, value)
+	}
+	return row, periodErr
+}
+
+// checkDuplicatePeriods fails when a period of the row was already read for its region, which
+// happens when the filter lets through several measures of the indicator.
+func checkDuplicatePeriods(row *domain.SourceRow, periods map[string]int) error {
+	region := domain.NormalizeRegionCode(row.Cells[0])
+	seen := make(map[string]bool)
+	for _, period := range row.Header[1:] {
+		if seen[period] {
+			return fmt.Errorf("period [%s] appears more than once in the series", period)
+		}
+		seen[period] = true
+		if previous, ok := periods[region+"|"+period]; ok {
+			return fmt.Errorf("period [%s] of region [%s] was already read from series %d, narrow the series filter", period, row.Cells[0], previous)
+		}
+	}
+	for period := range seen {
+		periods[region+"|"+period] = row.RowNumber
+	}
+	return nil
+}
+
+var (
+	sdmxYear    = regexp.MustCompile(`^(\d{4})(?:-A1)?$`)
+	sdmxQuarter = regexp.MustCompile(`^(\d{4})-?Q([1-4])$`)
+	// fedstatQuarter is a quarter named in a period dimension, such as "I квартал".
+	fedstatQuarter = regexp.MustCompile(`^(i|ii|iii|iv|[1-4])\s*квартал$`)
+	// fedstatYear is the annual total named in a period dimension.
+	fedstatYear = regexp.MustCompile(`^(год|январь\s*-\s*декабрь)$`)
+)
+
+var romanQuarters = map[string]int{"i": 1, "ii": 2, "iii": 3, "iv": 4}
+
+// sdmxPeriod converts an observation time, refined by the period label when the source has a
+// period dimension, into the "YYYY.Q" or "YYYY" header of a source row.
+func sdmxPeriod(timePeriod, periodLabel string) (string, error) {
+	timePeriod = strings.TrimSpace(timePeriod)
+	if match := sdmxQuarter.FindStringSubmatch(timePeriod); match != nil && periodLabel == "" {
+		return match[1] + "." + match[2], nil
+	}
+	match := sdmxYear.FindStringSubmatch(timePeriod)
+	if match == nil {
+		return "", fmt.Errorf("unsupported time period [%s], expected a year or a quarter", timePeriod)
+	}
+	if periodLabel == "" {
+		return match[1], nil
+	}
+
+	label := strings.ToLower(strings.Join(strings.Fields(periodLabel), " "))
+	if fedstatYear.MatchString(label) {
+		return match[1], nil
+	}
+	quarter := fedstatQuarter.FindStringSubmatch(label)
+	if quarter == nil {
+		return "", fmt.Errorf("unsupported period [%s] of year [%s], expected a quarter or the year", periodLabel, timePeriod)
+	}
+	number, ok := romanQuarters[quarter[1]]
+	if !ok {
+		number, _ = strconv.Atoi(quarter[1])
+	}
+	return fmt.Sprintf("%s.%d", match[1], number), nil
+}
+
+// readSDMXSeries streams the series of a data message. Elements are matched by local name, so
+// the namespace prefixes of the SDMX versions do not matter:
+// - generic: Series/SeriesKey/Value and Obs with Time (2.0) or ObsDimension (2.1) and ObsValue;
+// - structure-specific and compact: the key as attributes of Series and Obs, the value as OBS_VALUE.
+// Attribute blocks of the generic form are skipped.
+func readSDMXSeries(content io.Reader, timeDimension string) ([]*sdmxSeries, int, error) {
+	decoder := xml.NewDecoder(content)
+	decoder.CharsetReader = sdmxCharsetReader
+
+	var (
+		series       []*sdmxSeries
+		dataSets     int
+		current      *sdmxSeries
+		observation  *sdmxObservation
+		key          map[string]string
+		inAttributes bool
+		inTime       bool
+		timeText     strings.Builder
+	)
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "DataSet":
+				dataSets++
+			case "Series":
+				current = &sdmxSeries{dataSet: dataSets, key: sdmxAttrs(element)}
+			case "SeriesKey":
+				if current != nil {
+					key = current.key
+				}
+			case "ObsKey":
+				if observation != nil {
+					key = observation.key
+				}
+			case "Attributes":
+				inAttributes = true
+			case "Value":
+				if key != nil && !inAttributes {
+					dimension := xmlAttr(element, "id")
+					if dimension == "" {
+						dimension = xmlAttr(element, "concept")
+					}
+					key[dimension] = xmlAttr(element, "value")
+				}
+			case "Obs":
+				observation = &sdmxObservation{key: sdmxAttrs(element)}
+				observation.value = observation.key["OBS_VALUE"]
+			case "ObsDimension":
+				if observation != nil {
+					dimension := xmlAttr(element, "id")
+					if dimension == "" {
+						dimension = timeDimension
+					}
+					observation.key[dimension] = xmlAttr(element, "value")
+				}
+			case "Time":
+				if observation != nil {
+					inTime = true
+					timeText.Reset()
+				}
+			case "ObsValue":
+				if observation != nil {
+					observation.value = xmlAttr(element, "value")
+				}
+			}
+		case xml.CharData:
+			if inTime {
+				timeText.Write(element)
+			}
+		case xml.EndElement:
+			switch element.Name.Local {
+			case "SeriesKey", "ObsKey":
+				key = nil
+			case "Attributes":
+				inAttributes = false
+			case "Time":
+				if inTime {
+					observation.key[timeDimension] = strings.TrimSpace(timeText.String())
+					inTime = false
+				}
+			case "Obs":
+				if observation == nil {
+					continue
+				}
+				if current != nil {
+					current.observations = append(current.observations, observation)
+				} else {
+					series = append(series, &sdmxSeries{
+						dataSet:      dataSets,
+						key:          observation.key,
+						observations: []*sdmxObservation{observation},
+					})
+				}
+				observation = nil
+			case "Series":
+				if current != nil {
+					series = append(series, current)
+				}
+				current = nil
+			}
+		}
+	}
+	return series, dataSets, nil
+}
+
+// sdmxCharsetReader decodes messages declared in an encoding other than UTF-8, such as the
+// windows-1251 of older exports.
+func sdmxCharsetReader(label string, input io.Reader) (io.Reader, error) {
+	encoding, err := htmlindex.Get(label)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported encoding [%s]: %w", label, err)
+	}
+	return encoding.NewDecoder().Reader(input), nil
+}
+
+// sdmxAttrs returns the unqualified attributes of an element, which hold the dimensions and
+// attributes of series and observations in the structure-specific form.
+func sdmxAttrs(element xml.StartElement) map[string]string {
+	attrs := make(map[string]string)
+	for _, attr := range element.Attr {
+		if attr.Name.Space == "" && attr.Name.Local != "xmlns" {
+			attrs[attr.Name.Local] = attr.Value
+		}
+	}
+	return attrs
+}
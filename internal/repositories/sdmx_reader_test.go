@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"log/slog"
+	"sort"
+	"testing"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+type sdmxIncome struct {
+	RegionCode string
+	Period     domain.PeriodType
+	Year       int32
+	Quarter    int32
+	Value      string
+	Missing    bool
+}
+
+func sdmxIncomes(t *testing.T, source *domain.SDMXSource, path string) ([]sdmxIncome, []*domain.IngestionReject) {
+	t.Helper()
+	parsed, err := NewSDMXReader(slog.Default(), source).ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 1, parsed.SheetCount)
+
+	incomes := make([]sdmxIncome, 0, len(parsed.Incomes))
+	for _, income := range parsed.Incomes {
+		require.Equal(t, income.RegionCode, income.Region)
+		require.NotNil(t, income.Source)
+		incomes = append(incomes, sdmxIncome{
+			RegionCode: income.RegionCode,
+			Period:     income.PeriodType,
+			Year:       income.Year,
+			Quarter:    income.Quarter,
+			Value:      income.AverageRegionIncomes.String(),
+			Missing:    income.Missing,
+		})
+	}
+	sortSDMXIncomes(incomes)
+	return incomes, parsed.Rejects
+}
+
+func sortSDMXIncomes(incomes []sdmxIncome) {
+	sort.Slice(incomes, func(i, j int) bool {
+		a, b := incomes[i], incomes[j]
+		if a.RegionCode != b.RegionCode {
+			return a.RegionCode < b.RegionCode
+		}
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		return a.Quarter < b.Quarter
+	})
+}
+
+func TestSDMXReaderReadsGenericAndStructureSpecificMessagesAlike(t *testing.T) {
+	genericSource := domain.DefaultSDMXSource()
+	genericSource.PeriodDimension = "PERIOD"
+	genericSource.SeriesFilter = map[string]string{"EI": "рубль"}
+
+	structureSpecificSource := domain.DefaultSDMXSource()
+	structureSpecificSource.RegionDimension = "REF_AREA"
+	structureSpecificSource.SeriesFilter = map[string]string{"UNIT_MEASURE": "RUB"}
+
+	tests := []struct {
+		name        string
+		source      *domain.SDMXSource
+		path        string
+		region      string
+		otherRegion string
+		rejectedBy  string
+	}{
+		{
+			name:        "generic",
+			source:      &genericSource,
+			path:        "testdata/sdmx_generic.xml",
+			region:      "80000000",
+			otherRegion: "45000000",
+			rejectedBy:  "unsupported period [январь] of year [2024]",
+		},
+		{
+			name:        "structure specific",
+			source:      &structureSpecificSource,
+			path:        "testdata/sdmx_structure_specific.xml",
+			region:      "RU-BA",
+			otherRegion: "RU-MOW",
+			rejectedBy:  "unsupported time period [2024-01]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incomes, rejects := sdmxIncomes(t, tt.source, tt.path)
+			expected := []sdmxIncome{
+				{RegionCode: tt.otherRegion, Period: domain.PeriodQuarter, Year: 2024, Quarter: 1, Value: "95000.75"},
+				{RegionCode: tt.region, Period: domain.PeriodYear, Year: 2024, Value: "42750"},
+				{RegionCode: tt.region, Period: domain.PeriodQuarter, Year: 2024, Quarter: 1, Value: "38000.5"},
+				{RegionCode: tt.region, Period: domain.PeriodQuarter, Year: 2024, Quarter: 2, Value: "41000"},
+				{RegionCode: tt.region, Period: domain.PeriodQuarter, Year: 2024, Quarter: 3, Value: "0", Missing: true},
+				{RegionCode: tt.region, Period: domain.PeriodQuarter, Year: 2024, Quarter: 4, Value: "50000"},
+				{RegionCode: tt.region, Period: domain.PeriodQuarter, Year: 2025, Quarter: 1, Value: "40000"},
+			}
+			sortSDMXIncomes(expected)
+			require.Equal(t, expected, incomes)
+
+			require.Len(t, rejects, 1)
+			require.Equal(t, tt.otherRegion, rejects[0].Row.Cells[0])
+			require.Contains(t, rejects[0].Reason, tt.rejectedBy)
+		})
+	}
+}
+
+func TestSDMXReaderRejectsSeriesRepeatingAPeriod(t *testing.T) {
+	source := domain.DefaultSDMXSource()
+	source.RegionDimension = "REF_AREA"
+	source.SeriesFilter = map[string]string{"FREQ": "Q"}
+
+	incomes, rejects := sdmxIncomes(t, &source, "testdata/sdmx_structure_specific.xml")
+	require.Len(t, incomes, 6)
+	require.Len(t, rejects, 1)
+	require.Equal(t, []string{"RU-BA", "112.4"}, rejects[0].Row.Cells)
+	require.Equal(t, "period [2024.1] of region [RU-BA] was already read from series 1, narrow the series filter", rejects[0].Reason)
+}
+
+func TestSDMXReaderFailsWhenNoSeriesMatchTheFilter(t *testing.T) {
+	source := domain.DefaultSDMXSource()
+	source.RegionDimension = "REF_AREA"
+	source.SeriesFilter = map[string]string{"UNIT_MEASURE": "USD"}
+
+	_, err := NewSDMXReader(slog.Default(), &source).ReadFile("testdata/sdmx_structure_specific.xml")
+	require.ErrorContains(t, err, "no series of the message match the series filter [UNIT_MEASURE=USD]")
+}
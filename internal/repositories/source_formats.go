@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/xuri/excelize/v2"
+)
+
+// SourceFormat opens one file format of the source as a workbook, so that the sheet layout and
+// the row parsing apply to every format alike.
+type SourceFormat interface {
+	// Name identifies the format in logs and errors.
+	Name() string
+	// Sniff reports whether the file is in this format, judging by its first bytes and its name.
+	Sniff(filePath string, head []byte) bool
+	Open(filePath string) (*excelize.File, error)
+}
+
+// SourceFormats is a registry of source formats, tried in order.
+type SourceFormats []SourceFormat
+
+// sniffLength is how many leading bytes of a file are passed to Sniff.
+const sniffLength = 512
+
+// defaultSheetName is the only sheet of a new workbook.
+const defaultSheetName = "Sheet1"
+
+// DefaultSourceFormats knows XLSX, ODS, legacy XLS and CSV files. ODS and XLSX are both zip
+// archives, so ODS is tried first by its mimetype entry; CSV is the fallback for any text file, and
+// its delimiter is chosen on the header rows of the layout.
+func DefaultSourceFormats(layout *domain.SheetLayout) SourceFormats {
+	return SourceFormats{odsFormat{}, xlsxFormat{}, xlsFormat{}, csvFormat{headerRows: []int{layout.HeaderRow, layout.SubHeaderRow}}}
+}
+
+// Detect picks the format of a file from its contents, falling back to its extension.
+func (f SourceFormats) Detect(filePath string) (SourceFormat, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	head = head[:n]
+
+	for _, format := range f {
+		if format.Sniff(filePath, head) {
+			return format, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown format of file [%s]", filepath.Base(filePath))
+}
+
+// errUnsupportedFormat marks a file that its format recognizes but cannot read, such as an
+// encrypted workbook: opening it again does not help.
+var errUnsupportedFormat = errors.New("unsupported file format")
+
+var (
+	zipMagic = []byte("PK\x03\x04")
+	// oleMagic starts the compound document of a legacy XLS workbook.
+	oleMagic = []byte("\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1")
+)
+
+func hasExtension(filePath string, extensions ...string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	for _, extension := range extensions {
+		if ext == extension {
+			return true
+		}
+	}
+	return false
+}
+
+type xlsxFormat struct{}
+
+func (xlsxFormat) Name() string { return "xlsx" }
+
+func (xlsxFormat) Sniff(filePath string, head []byte) bool {
+	return bytes.HasPrefix(head, zipMagic) || (len(head) == 0 && hasExtension(filePath, ".xlsx", ".xlsm"))
+}
+
+func (xlsxFormat) Open(filePath string) (*excelize.File, error) {
+	return excelize.OpenFile(filePath)
+}
@@ -0,0 +1,553 @@
+package repositories
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding/charmap"
+)
+
+var sourceRows = [][]string{
+	{"Среднедушевые денежные доходы населения"},
+	{"рублей в месяц"},
+	{"", "2024 год", "", "", "", "", "2025 год"},
+	{"", "I квартал", "II квартал", "III квартал", "IV квартал", "год", "I квартал"},
+	{"Российская Федерация", "46000", "50000", "52000", "60000", "52000", "49000"},
+	{"Республика Башкортостан", "38000,5", "41000", "…", "50000", "42750", "40000"},
+	{"1) Без учёта статистической информации по отдельным регионам"},
+	{"2) Данные предварительные"},
+	{""},
+	{"Источник: Росстат"},
+}
+
+func writeXLSX(t *testing.T, path string) {
+	t.Helper()
+	file := excelize.NewFile()
+	defer file.Close()
+	for idx, row := range sourceRows {
+		cell, err := excelize.CoordinatesToCellName(1, idx+1)
+		require.NoError(t, err)
+		require.NoError(t, file.SetSheetRow("Sheet1", cell, &row))
+	}
+	require.NoError(t, file.SaveAs(path))
+}
+
+func csvContent() string {
+	lines := make([]string, 0, len(sourceRows))
+	for _, row := range sourceRows {
+		lines = append(lines, strings.Join(row, ";"))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// commaCSVContent writes the rows with a comma, quoting the decimal commas, and puts a semicolon
+// in a footnote.
+func commaCSVContent(t *testing.T) string {
+	t.Helper()
+	var content strings.Builder
+	writer := csv.NewWriter(&content)
+	for idx, row := range sourceRows {
+		if idx == 6 {
+			row = []string{row[0] + "; без учёта автономных округов"}
+		}
+		require.NoError(t, writer.Write(row))
+	}
+	writer.Flush()
+	require.NoError(t, writer.Error())
+	return content.String()
+}
+
+func writeODS(t *testing.T, path string) {
+	t.Helper()
+	var content strings.Builder
+	content.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"><office:body><office:spreadsheet><table:table table:name="Sheet1">`)
+	for _, row := range sourceRows {
+		content.WriteString(`<table:table-row>`)
+		for _, cell := range row {
+			switch {
+			case cell == "":
+				content.WriteString(`<table:table-cell/>`)
+			case cell == "38000,5":
+				content.WriteString(`<table:table-cell office:value-type="float" office:value="38000.5"><text:p>38<text:s/>000,50</text:p></table:table-cell>`)
+			default:
+				content.WriteString(`<table:table-cell office:value-type="string"><text:p>` + cell + `</text:p></table:table-cell>`)
+			}
+		}
+		content.WriteString(`<table:table-cell table:number-columns-repeated="1016"/></table:table-row>`)
+	}
+	content.WriteString(`<table:table-row table:number-rows-repeated="1048566"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>`)
+	content.WriteString(`</table:table></office:spreadsheet></office:body></office:document-content>`)
+
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	archive := zip.NewWriter(file)
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	require.NoError(t, err)
+	_, err = mimetype.Write([]byte(odsMimeType))
+	require.NoError(t, err)
+	contentXML, err := archive.Create("content.xml")
+	require.NoError(t, err)
+	_, err = contentXML.Write([]byte(content.String()))
+	require.NoError(t, err)
+	require.NoError(t, archive.Close())
+}
+
+// appendXLSRecord appends a BIFF8 record to a workbook stream.
+func appendXLSRecord(stream []byte, kind uint16, data []byte) []byte {
+	stream = binary.LittleEndian.AppendUint16(stream, kind)
+	stream = binary.LittleEndian.AppendUint16(stream, uint16(len(data)))
+	return append(stream, data...)
+}
+
+// appendXLSChars appends characters compressed to their low bytes when they allow it, or else as
+// UTF-16, and returns the flags byte telling which.
+func appendXLSChars(data []byte, chars []rune) ([]byte, byte) {
+	for _, char := range chars {
+		if char > 0xFF {
+			for _, unit := range utf16.Encode(chars) {
+				data = binary.LittleEndian.AppendUint16(data, unit)
+			}
+			return data, 0x01
+		}
+	}
+	for _, char := range chars {
+		data = append(data, byte(char))
+	}
+	return data, 0x00
+}
+
+// xlsSharedStrings writes a shared string table split into an SST record and CONTINUE records
+// of at most limit bytes. A string is also split where its characters switch between ones that
+// compress to a byte and ones that do not, so that its parts are stored differently.
+func xlsSharedStrings(strings []string, limit int) [][]byte {
+	segments := [][]byte{binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(nil, uint32(len(strings))), uint32(len(strings)))}
+	for _, value := range strings {
+		chars := []rune(value)
+		// A string header is never split.
+		if len(segments[len(segments)-1])+4 > limit {
+			segments = append(segments, nil)
+		}
+		segment := &segments[len(segments)-1]
+		*segment = binary.LittleEndian.AppendUint16(*segment, uint16(len(utf16.Encode(chars))))
+		flagsAt := len(*segment)
+		*segment = append(*segment, 0)
+		for first := true; first || len(chars) > 0; first = false {
+			if !first {
+				segments = append(segments, []byte{0})
+				segment = &segments[len(segments)-1]
+				flagsAt = 0
+			}
+			wide := len(chars) > 0 && chars[0] > 0xFF
+			charSize := 1
+			if wide {
+				charSize = 2
+			}
+			count := 0
+			for count < len(chars) && (chars[count] > 0xFF) == wide && (count+1)*charSize <= limit-len(*segment) {
+				count++
+			}
+			var flags byte
+			*segment, flags = appendXLSChars(*segment, chars[:count])
+			(*segment)[flagsAt] = flags
+			chars = chars[count:]
+		}
+	}
+	return segments
+}
+
+func xlsCell(row, column int) []byte {
+	data := binary.LittleEndian.AppendUint16(nil, uint16(row))
+	data = binary.LittleEndian.AppendUint16(data, uint16(column))
+	return binary.LittleEndian.AppendUint16(data, 0)
+}
+
+// xlsRKValue encodes a whole number as an RK value.
+func xlsRKValue(value int) uint32 {
+	return uint32(value)<<2 | 0x02
+}
+
+// writeXLS writes the rows as an Excel 97-2003 workbook the way Excel stores them: text in the
+// shared string table or, for a cell, inline; whole numbers as RK and MULRK records, a fraction
+// as a NUMBER record and one figure as the cached result of a formula.
+func writeXLS(t *testing.T, path string) {
+	t.Helper()
+	bof := func(kind uint16) []byte {
+		data := binary.LittleEndian.AppendUint16(nil, 0x0600)
+		data = binary.LittleEndian.AppendUint16(data, kind)
+		return append(data, make([]byte, 12)...)
+	}
+
+	var sheet []byte
+	sheet = appendXLSRecord(sheet, 0x0809, bof(0x0010))
+	var sharedStrings []string
+	for rowIdx, row := range sourceRows {
+		// The whole numbers of a row but its last one go to MULRK records.
+		inRun := func(column int) bool {
+			_, err := strconv.Atoi(row[column])
+			return err == nil && row[column] != "41000" && column < len(row)-1
+		}
+		for column := 0; column < len(row); column++ {
+			cell := row[column]
+			value, err := strconv.Atoi(cell)
+			switch {
+			case err != nil:
+			case cell == "41000":
+				data := binary.LittleEndian.AppendUint64(xlsCell(rowIdx, column), math.Float64bits(float64(value)))
+				sheet = appendXLSRecord(sheet, 0x0006, append(data, make([]byte, 6)...))
+				continue
+			case inRun(column) && inRun(column+1):
+				data := binary.LittleEndian.AppendUint16(binary.LittleEndian.AppendUint16(nil, uint16(rowIdx)), uint16(column))
+				for ; inRun(column); column++ {
+					value, _ := strconv.Atoi(row[column])
+					data = binary.LittleEndian.AppendUint16(data, 0)
+					data = binary.LittleEndian.AppendUint32(data, xlsRKValue(value))
+				}
+				column--
+				sheet = appendXLSRecord(sheet, 0x00BD, binary.LittleEndian.AppendUint16(data, uint16(column)))
+				continue
+			default:
+				sheet = appendXLSRecord(sheet, 0x027E, binary.LittleEndian.AppendUint32(xlsCell(rowIdx, column), xlsRKValue(value)))
+				continue
+			}
+			switch cell {
+			case "":
+			case "38000,5":
+				sheet = appendXLSRecord(sheet, 0x0203, binary.LittleEndian.AppendUint64(xlsCell(rowIdx, column), math.Float64bits(38000.5)))
+			case "…":
+				data := binary.LittleEndian.AppendUint16(xlsCell(rowIdx, column), uint16(len([]rune(cell))))
+				chars, flags := appendXLSChars(nil, []rune(cell))
+				sheet = appendXLSRecord(sheet, 0x0204, append(append(data, flags), chars...))
+			default:
+				sheet = appendXLSRecord(sheet, 0x00FD, binary.LittleEndian.AppendUint32(xlsCell(rowIdx, column), uint32(len(sharedStrings))))
+				sharedStrings = append(sharedStrings, cell)
+			}
+		}
+	}
+	sheet = appendXLSRecord(sheet, 0x000A, nil)
+
+	boundSheet := func(offset int) []byte {
+		data := binary.LittleEndian.AppendUint32(nil, uint32(offset))
+		data = append(data, 0, 0, byte(len("Sheet1")), 0)
+		return append(data, "Sheet1"...)
+	}
+	var globals []byte
+	globals = appendXLSRecord(globals, 0x0809, bof(0x0005))
+	globals = appendXLSRecord(globals, 0x0085, boundSheet(0))
+	for idx, segment := range xlsSharedStrings(sharedStrings, 40) {
+		kind := uint16(0x003C)
+		if idx == 0 {
+			kind = 0x00FC
+		}
+		globals = appendXLSRecord(globals, kind, segment)
+	}
+	globals = appendXLSRecord(globals, 0x000A, nil)
+	binary.LittleEndian.PutUint32(globals[4+16+4:], uint32(len(globals)))
+
+	stream := append(globals, sheet...)
+	// Streams shorter than 4096 bytes go to the mini stream, which this container does without.
+	if len(stream) < 4092 {
+		stream = appendXLSRecord(stream, 0x0000, make([]byte, 4092-len(stream)))
+	}
+	require.NoError(t, os.WriteFile(path, compoundDocument("Workbook", stream), 0o644))
+}
+
+// compoundDocument wraps a workbook stream in a version 3 compound document: a FAT sector, the
+// stream sectors and a directory sector holding the root entry and the named stream.
+func compoundDocument(name string, stream []byte) []byte {
+	const (
+		sectorSize = 512
+		freeSector = 0xFFFFFFFF
+		endOfChain = 0xFFFFFFFE
+		fatSector  = 0xFFFFFFFD
+		noStream   = 0xFFFFFFFF
+	)
+	streamSectors := (len(stream) + sectorSize - 1) / sectorSize
+	dirSector := streamSectors + 1
+
+	header := append([]byte(nil), oleMagic...)
+	header = append(header, make([]byte, 16)...)
+	for _, value := range []uint16{0x003E, 0x0003, 0xFFFE, 9, 6} {
+		header = binary.LittleEndian.AppendUint16(header, value)
+	}
+	header = append(header, make([]byte, 6)...)
+	for _, value := range []uint32{0, 1, uint32(dirSector), 0, 4096, endOfChain, 0, endOfChain, 0, 0} {
+		header = binary.LittleEndian.AppendUint32(header, value)
+	}
+	for len(header) < sectorSize {
+		header = binary.LittleEndian.AppendUint32(header, freeSector)
+	}
+
+	fat := binary.LittleEndian.AppendUint32(nil, fatSector)
+	for sector := 1; sector < streamSectors; sector++ {
+		fat = binary.LittleEndian.AppendUint32(fat, uint32(sector+1))
+	}
+	fat = binary.LittleEndian.AppendUint32(fat, endOfChain)
+	fat = binary.LittleEndian.AppendUint32(fat, endOfChain)
+	for len(fat) < sectorSize {
+		fat = binary.LittleEndian.AppendUint32(fat, freeSector)
+	}
+
+	entry := func(name string, kind byte, child, start uint32, size int) []byte {
+		data := make([]byte, 128)
+		units := utf16.Encode([]rune(name))
+		for idx, unit := range units {
+			binary.LittleEndian.PutUint16(data[idx*2:], unit)
+		}
+		binary.LittleEndian.PutUint16(data[64:], uint16(len(units)*2+2))
+		data[66], data[67] = kind, 1
+		binary.LittleEndian.PutUint32(data[68:], noStream)
+		binary.LittleEndian.PutUint32(data[72:], noStream)
+		binary.LittleEndian.PutUint32(data[76:], child)
+		binary.LittleEndian.PutUint32(data[116:], start)
+		binary.LittleEndian.PutUint32(data[120:], uint32(size))
+		return data
+	}
+	directory := append(entry("Root Entry", 5, 1, endOfChain, 0), entry(name, 2, noStream, 1, len(stream))...)
+	for len(directory) < sectorSize {
+		directory = append(directory, entry("", 0, noStream, freeSector, 0)...)
+	}
+
+	document := append(append(header, fat...), stream...)
+	document = append(document, make([]byte, streamSectors*sectorSize-len(stream))...)
+	return append(document, directory...)
+}
+
+type parsedIncome struct {
+	Region  string
+	Period  domain.PeriodType
+	Year    int32
+	Quarter int32
+	Value   string
+	Missing bool
+}
+
+func parsedIncomes(t *testing.T, path string) []parsedIncome {
+	t.Helper()
+	layout := domain.DefaultSheetLayout()
+	reader := NewExcelReader(slog.Default(), 1, 0, &layout)
+	parsed, err := reader.ReadFile(path)
+	require.NoError(t, err)
+	require.Empty(t, parsed.Rejects)
+
+	incomes := make([]parsedIncome, 0, len(parsed.Incomes))
+	for _, income := range parsed.Incomes {
+		require.Equal(t, "Sheet1", income.Sheet)
+		incomes = append(incomes, parsedIncome{
+			Region:  income.Region,
+			Period:  income.PeriodType,
+			Year:    income.Year,
+			Quarter: income.Quarter,
+			Value:   income.AverageRegionIncomes.String(),
+			Missing: income.Missing,
+		})
+	}
+	return incomes
+}
+
+func TestReadFileParsesEverySourceFormatAlike(t *testing.T) {
+	layout := domain.DefaultSheetLayout()
+	dir := t.TempDir()
+	xlsxPath := filepath.Join(dir, "incomes.xlsx")
+	writeXLSX(t, xlsxPath)
+	expected := parsedIncomes(t, xlsxPath)
+	require.Len(t, expected, 12)
+	require.Equal(t, parsedIncome{Region: "Республика Башкортостан", Period: domain.PeriodQuarter, Year: 2024, Quarter: 1, Value: "38000.5"}, expected[6])
+	require.True(t, expected[8].Missing)
+
+	windows1251, err := charmap.Windows1251.NewEncoder().String(csvContent())
+	require.NoError(t, err)
+
+	files := []struct {
+		name    string
+		format  string
+		content string
+	}{
+		{name: "incomes.csv", format: "csv", content: csvContent()},
+		{name: "incomes-1251.csv", format: "csv", content: windows1251},
+		{name: "incomes-comma.csv", format: "csv", content: commaCSVContent(t)},
+		// The extension does not match the contents, which decide.
+		{name: "incomes.xlsx.download", format: "csv", content: "\xEF\xBB\xBF" + csvContent()},
+	}
+	for _, file := range files {
+		t.Run(file.name, func(t *testing.T) {
+			path := filepath.Join(dir, file.name)
+			require.NoError(t, os.WriteFile(path, []byte(file.content), 0o644))
+			format, err := DefaultSourceFormats(&layout).Detect(path)
+			require.NoError(t, err)
+			require.Equal(t, file.format, format.Name())
+			require.Equal(t, expected, parsedIncomes(t, path))
+		})
+	}
+
+	t.Run("incomes.xls", func(t *testing.T) {
+		path := filepath.Join(dir, "incomes.xls")
+		writeXLS(t, path)
+		format, err := DefaultSourceFormats(&layout).Detect(path)
+		require.NoError(t, err)
+		require.Equal(t, "xls", format.Name())
+		require.Equal(t, expected, parsedIncomes(t, path))
+	})
+
+	t.Run("incomes.ods", func(t *testing.T) {
+		path := filepath.Join(dir, "incomes.ods")
+		writeODS(t, path)
+		format, err := DefaultSourceFormats(&layout).Detect(path)
+		require.NoError(t, err)
+		require.Equal(t, "ods", format.Name())
+		require.Equal(t, expected, parsedIncomes(t, path))
+	})
+}
+
+func TestReadFileFailsOnUnknownFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "incomes_2026-10-18")
+	require.NoError(t, os.WriteFile(path, []byte("\x00\x01\x02binary"), 0o644))
+
+	layout := domain.DefaultSheetLayout()
+	_, err := NewExcelReader(slog.Default(), 1, 0, &layout).ReadFile(path)
+	require.ErrorContains(t, err, "unknown format of file [incomes_2026-10-18]")
+}
+
+func TestDetectRejectsMarkup(t *testing.T) {
+	layout := domain.DefaultSheetLayout()
+	files := []struct {
+		name    string
+		content string
+	}{
+		{name: "incomes.csv", content: "\xEF\xBB\xBF\r\n  <!DOCTYPE html><html><body>502 Bad Gateway</body></html>"},
+		{name: "incomes.xlsx.download", content: "Error\n<html><body>Access denied</body></html>"},
+		{name: "incomes.txt", content: `<?xml version="1.0"?><error>not found</error>`},
+	}
+	for _, file := range files {
+		t.Run(file.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), file.name)
+			require.NoError(t, os.WriteFile(path, []byte(file.content), 0o644))
+			_, err := DefaultSourceFormats(&layout).Detect(path)
+			require.ErrorContains(t, err, "unknown format of file ["+file.name+"]")
+		})
+	}
+}
+
+func TestReadFileRejectsUnreadableXLS(t *testing.T) {
+	padded := func(stream []byte) []byte {
+		return appendXLSRecord(stream, 0x0000, make([]byte, 4092-len(stream)))
+	}
+	biff8 := appendXLSRecord(nil, 0x0809, append([]byte{0x00, 0x06, 0x05, 0x00}, make([]byte, 12)...))
+
+	files := []struct {
+		name    string
+		content []byte
+		error   string
+	}{
+		{
+			name:    "corrupt container",
+			content: append(append([]byte(nil), oleMagic...), make([]byte, 504)...),
+			error:   "failed to read xls compound document",
+		},
+		{
+			name:    "excel 95",
+			content: compoundDocument("Book", padded(appendXLSRecord(nil, 0x0809, []byte{0x00, 0x05, 0x05, 0x00}))),
+			error:   "unsupported file format: Excel 5.0/95 workbook",
+		},
+		{
+			name:    "encrypted",
+			content: compoundDocument("Workbook", padded(appendXLSRecord(appendXLSRecord(biff8, 0x002F, make([]byte, 6)), 0x000A, nil))),
+			error:   "unsupported file format: encrypted workbook",
+		},
+	}
+	layout := domain.DefaultSheetLayout()
+	for _, file := range files {
+		t.Run(file.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "incomes.xls")
+			require.NoError(t, os.WriteFile(path, file.content, 0o644))
+			_, err := NewExcelReader(slog.Default(), 1, 0, &layout).ReadFile(path)
+			require.ErrorContains(t, err, file.error)
+		})
+	}
+}
+
+// failingFormat fails to open every file with its error and counts the attempts.
+type failingFormat struct {
+	err   error
+	opens *int
+}
+
+func (failingFormat) Name() string { return "failing" }
+
+func (failingFormat) Sniff(string, []byte) bool { return true }
+
+func (f failingFormat) Open(string) (*excelize.File, error) {
+	*f.opens++
+	return nil, f.err
+}
+
+func TestReadFileRetriesOnlyTransientOpenErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "incomes.xlsx")
+	require.NoError(t, os.WriteFile(path, nil, 0o644))
+
+	tests := []struct {
+		name  string
+		err   error
+		opens int
+		logs  []string
+	}{
+		{
+			name:  "transient",
+			err:   errors.New("file is locked"),
+			opens: 3,
+			logs: []string{
+				`level=ERROR msg="failed to get file, retrying..." attempt=1 err="file is locked"`,
+				`level=ERROR msg="failed to get file, retrying..." attempt=2 err="file is locked"`,
+				`level=ERROR msg="failed to get file, retrying..." attempt=3 err="file is locked"`,
+			},
+		},
+		{
+			name:  "unsupported",
+			err:   fmt.Errorf("%w: encrypted workbook", errUnsupportedFormat),
+			opens: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs strings.Builder
+			logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+					if attr.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+					return attr
+				},
+			}))
+			layout := domain.DefaultSheetLayout()
+			reader := NewExcelReader(logger, 3, 0, &layout)
+			opens := 0
+			reader.formats = SourceFormats{failingFormat{err: tt.err, opens: &opens}}
+
+			_, err := reader.ReadFile(path)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.opens, opens)
+			var retries []string
+			for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+				if strings.Contains(line, "retrying") {
+					retries = append(retries, line)
+				}
+			}
+			require.Equal(t, tt.logs, retries)
+		})
+	}
+}
@@ -0,0 +1,507 @@
+package repositories
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+
+	"github.com/richardlehane/mscfb"
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsFormat reads the worksheets of a legacy Excel 97-2003 (BIFF8) workbook into sheets of the
+// same names. Numeric cells keep their stored value, other cells their text; formulas give their
+// cached result. Excel 5.0/95 workbooks and encrypted workbooks are not read.
+type xlsFormat struct{}
+
+func (xlsFormat) Name() string { return "xls" }
+
+func (xlsFormat) Sniff(filePath string, head []byte) bool {
+	return bytes.HasPrefix(head, oleMagic) || (len(head) == 0 && hasExtension(filePath, ".xls"))
+}
+
+func (xlsFormat) Open(filePath string) (*excelize.File, error) {
+	source, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer source.Close()
+
+	stream, err := xlsWorkbookStream(source)
+	if err != nil {
+		return nil, err
+	}
+
+	file := excelize.NewFile()
+	if err := loadXLSWorkbook(stream, file); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to read xls workbook: %w", err)
+	}
+	return file, nil
+}
+
+// xlsWorkbookStream returns the Workbook stream of the compound document, which holds the
+// BIFF8 records.
+func xlsWorkbookStream(source io.ReaderAt) ([]byte, error) {
+	document, err := mscfb.New(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read xls compound document: %w", err)
+	}
+	for entry, err := document.Next(); err == nil; entry, err = document.Next() {
+		switch entry.Name {
+		case "Workbook":
+			stream := make([]byte, entry.Size)
+			if _, err := io.ReadFull(entry, stream); err != nil {
+				return nil, fmt.Errorf("failed to read xls workbook stream: %w", err)
+			}
+			return stream, nil
+		case "Book":
+			return nil, fmt.Errorf("%w: Excel 5.0/95 workbook, the file must be saved as XLSX, ODS or CSV", errUnsupportedFormat)
+		}
+	}
+	return nil, fmt.Errorf("%w: compound document has no Excel workbook stream", errUnsupportedFormat)
+}
+
+// BIFF8 record types read by loadXLSWorkbook.
+const (
+	xlsRecordFormula    = 0x0006
+	xlsRecordEOF        = 0x000A
+	xlsRecordFilePass   = 0x002F
+	xlsRecordContinue   = 0x003C
+	xlsRecordBoundSheet = 0x0085
+	xlsRecordMulRK      = 0x00BD
+	xlsRecordSST        = 0x00FC
+	xlsRecordLabelSST   = 0x00FD
+	xlsRecordNumber     = 0x0203
+	xlsRecordLabel      = 0x0204
+	xlsRecordString     = 0x0207
+	xlsRecordRK         = 0x027E
+	xlsRecordBOF        = 0x0809
+)
+
+const xlsBIFF8 = 0x0600
+
+type xlsRecord struct {
+	kind   uint16
+	data   []byte
+	offset int
+}
+
+// xlsRecords splits a stream, or the part of it from offset, into records.
+func xlsRecords(stream []byte, offset int) ([]xlsRecord, error) {
+	var records []xlsRecord
+	for offset < len(stream) {
+		if offset+4 > len(stream) {
+			return nil, fmt.Errorf("truncated record header at offset %d", offset)
+		}
+		kind := binary.LittleEndian.Uint16(stream[offset:])
+		size := int(binary.LittleEndian.Uint16(stream[offset+2:]))
+		if offset+4+size > len(stream) {
+			return nil, fmt.Errorf("truncated record [%#04x] at offset %d", kind, offset)
+		}
+		records = append(records, xlsRecord{kind: kind, data: stream[offset+4 : offset+4+size], offset: offset})
+		offset += 4 + size
+	}
+	return records, nil
+}
+
+type xlsSheet struct {
+	name   string
+	offset int
+}
+
+// loadXLSWorkbook reads the workbook globals, which list the worksheets and hold the shared
+// strings, and then the cells of every worksheet.
+func loadXLSWorkbook(stream []byte, file *excelize.File) error {
+	records, err := xlsRecords(stream, 0)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 || records[0].kind != xlsRecordBOF || len(records[0].data) < 4 {
+		return fmt.Errorf("workbook stream does not start with a BOF record")
+	}
+	if version := binary.LittleEndian.Uint16(records[0].data); version != xlsBIFF8 {
+		return fmt.Errorf("%w: BIFF version [%#04x], only Excel 97-2003 workbooks are read", errUnsupportedFormat, version)
+	}
+
+	var (
+		sheets  []xlsSheet
+		strings []string
+	)
+globals:
+	for idx := 1; idx < len(records); idx++ {
+		record := records[idx]
+		switch record.kind {
+		case xlsRecordFilePass:
+			return fmt.Errorf("%w: encrypted workbook", errUnsupportedFormat)
+		case xlsRecordBoundSheet:
+			if len(record.data) < 8 {
+				return fmt.Errorf("invalid sheet record")
+			}
+			// Only worksheets are read; chart and macro sheets have no cells.
+			if record.data[5] != 0 {
+				continue
+			}
+			name, _, err := xlsString(record.data[6:], 1)
+			if err != nil {
+				return fmt.Errorf("invalid sheet name: %w", err)
+			}
+			sheets = append(sheets, xlsSheet{name: name, offset: int(binary.LittleEndian.Uint32(record.data))})
+		case xlsRecordSST:
+			segments := [][]byte{record.data}
+			for idx+1 < len(records) && records[idx+1].kind == xlsRecordContinue {
+				idx++
+				segments = append(segments, records[idx].data)
+			}
+			if strings, err = readXLSSharedStrings(segments); err != nil {
+				return fmt.Errorf("invalid shared strings: %w", err)
+			}
+		case xlsRecordEOF:
+			break globals
+		}
+	}
+	if len(sheets) == 0 {
+		return fmt.Errorf("no worksheets found")
+	}
+
+	for idx, sheet := range sheets {
+		if idx == 0 {
+			err = file.SetSheetName(defaultSheetName, sheet.name)
+		} else {
+			_, err = file.NewSheet(sheet.name)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add sheet [%s]: %w", sheet.name, err)
+		}
+		if err := loadXLSSheet(stream, sheet, strings, file); err != nil {
+			return fmt.Errorf("sheet [%s]: %w", sheet.name, err)
+		}
+	}
+	return nil
+}
+
+// loadXLSSheet reads the cells of a worksheet substream, skipping the substreams of the charts
+// embedded in it.
+func loadXLSSheet(stream []byte, sheet xlsSheet, strings []string, file *excelize.File) error {
+	if sheet.offset < 0 || sheet.offset >= len(stream) {
+		return fmt.Errorf("offset %d is outside of the workbook stream", sheet.offset)
+	}
+	records, err := xlsRecords(stream, sheet.offset)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 || records[0].kind != xlsRecordBOF {
+		return fmt.Errorf("no BOF record at offset %d", sheet.offset)
+	}
+
+	rows := make(map[int]map[int]string)
+	setCell := func(row, column int, value string) {
+		if value == "" {
+			return
+		}
+		if rows[row] == nil {
+			rows[row] = make(map[int]string)
+		}
+		rows[row][column] = value
+	}
+	setRecordCell := func(data []byte, value string) {
+		row, column := xlsCellPosition(data)
+		setCell(row, column, value)
+	}
+
+	depth := 0
+	// formulaCell is the cell of a formula whose string result follows in a STRING record.
+	var formulaCell *[2]int
+	for _, record := range records {
+		switch record.kind {
+		case xlsRecordBOF:
+			depth++
+			continue
+		case xlsRecordEOF:
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+		if depth > 1 {
+			continue
+		}
+
+		data := record.data
+		if record.kind != xlsRecordString && record.kind != xlsRecordContinue {
+			formulaCell = nil
+		}
+		switch record.kind {
+		case xlsRecordLabelSST:
+			if len(data) < 10 {
+				return fmt.Errorf("invalid LABELSST record at offset %d", record.offset)
+			}
+			index := int(binary.LittleEndian.Uint32(data[6:]))
+			if index >= len(strings) {
+				return fmt.Errorf("shared string %d of %d referenced at offset %d", index, len(strings), record.offset)
+			}
+			setRecordCell(data, strings[index])
+		case xlsRecordLabel:
+			if len(data) < 6 {
+				return fmt.Errorf("invalid LABEL record at offset %d", record.offset)
+			}
+			value, _, err := xlsString(data[6:], 2)
+			if err != nil {
+				return fmt.Errorf("invalid LABEL record at offset %d: %w", record.offset, err)
+			}
+			setRecordCell(data, value)
+		case xlsRecordNumber:
+			if len(data) < 14 {
+				return fmt.Errorf("invalid NUMBER record at offset %d", record.offset)
+			}
+			setRecordCell(data, xlsNumber(math.Float64frombits(binary.LittleEndian.Uint64(data[6:]))))
+		case xlsRecordRK:
+			if len(data) < 10 {
+				return fmt.Errorf("invalid RK record at offset %d", record.offset)
+			}
+			setRecordCell(data, xlsNumber(xlsRK(binary.LittleEndian.Uint32(data[6:]))))
+		case xlsRecordMulRK:
+			if len(data) < 6 || (len(data)-6)%6 != 0 {
+				return fmt.Errorf("invalid MULRK record at offset %d", record.offset)
+			}
+			row := int(binary.LittleEndian.Uint16(data))
+			firstColumn := int(binary.LittleEndian.Uint16(data[2:]))
+			for idx := 0; idx < (len(data)-6)/6; idx++ {
+				rk := binary.LittleEndian.Uint32(data[4+idx*6+2:])
+				setCell(row, firstColumn+idx, xlsNumber(xlsRK(rk)))
+			}
+		case xlsRecordFormula:
+			if len(data) < 14 {
+				return fmt.Errorf("invalid FORMULA record at offset %d", record.offset)
+			}
+			row, column := xlsCellPosition(data)
+			result := data[6:14]
+			// A result whose last two bytes are all ones is not a number: its first byte tells
+			// a string, which follows in a STRING record, a boolean, an error or an empty string.
+			if binary.LittleEndian.Uint16(result[6:]) != 0xFFFF {
+				setCell(row, column, xlsNumber(math.Float64frombits(binary.LittleEndian.Uint64(result))))
+			} else if result[0] == 0 {
+				formulaCell = &[2]int{row, column}
+			}
+		case xlsRecordString:
+			if formulaCell == nil {
+				continue
+			}
+			value, _, err := xlsString(data, 2)
+			if err != nil {
+				return fmt.Errorf("invalid STRING record at offset %d: %w", record.offset, err)
+			}
+			setCell(formulaCell[0], formulaCell[1], value)
+			formulaCell = nil
+		}
+	}
+
+	rowNumbers := make([]int, 0, len(rows))
+	for row := range rows {
+		rowNumbers = append(rowNumbers, row)
+	}
+	sort.Ints(rowNumbers)
+	for _, row := range rowNumbers {
+		lastColumn := 0
+		for column := range rows[row] {
+			lastColumn = max(lastColumn, column)
+		}
+		values := make([]string, lastColumn+1)
+		for column, value := range rows[row] {
+			values[column] = value
+		}
+		cell, err := excelize.CoordinatesToCellName(1, row+1)
+		if err != nil {
+			return err
+		}
+		if err := file.SetSheetRow(sheet.name, cell, &values); err != nil {
+			return fmt.Errorf("failed to load row %d: %w", row+1, err)
+		}
+	}
+	return nil
+}
+
+// xlsCellPosition reads the zero-based row and column a cell record starts with.
+func xlsCellPosition(data []byte) (int, int) {
+	return int(binary.LittleEndian.Uint16(data)), int(binary.LittleEndian.Uint16(data[2:]))
+}
+
+// xlsRK decodes the compact number of RK and MULRK records: a 30-bit integer or the high bits
+// of a float, optionally multiplied by 100.
+func xlsRK(rk uint32) float64 {
+	var value float64
+	if rk&0x02 != 0 {
+		value = float64(int32(rk) >> 2)
+	} else {
+		value = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+	if rk&0x01 != 0 {
+		value /= 100
+	}
+	return value
+}
+
+func xlsNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// xlsString reads an unformatted BIFF8 string whose character count takes countSize bytes, and
+// returns it with the number of bytes it took.
+func xlsString(data []byte, countSize int) (string, int, error) {
+	if len(data) < countSize+1 {
+		return "", 0, fmt.Errorf("truncated string")
+	}
+	count := int(data[0])
+	if countSize == 2 {
+		count = int(binary.LittleEndian.Uint16(data))
+	}
+	flags := data[countSize]
+	chars := data[countSize+1:]
+	size := count
+	if flags&0x01 != 0 {
+		size *= 2
+	}
+	if len(chars) < size {
+		return "", 0, fmt.Errorf("truncated string")
+	}
+	return xlsChars(chars[:size], flags&0x01 != 0), countSize + 1 + size, nil
+}
+
+// xlsChars decodes string characters stored as UTF-16, or compressed to their low bytes.
+func xlsChars(data []byte, highByte bool) string {
+	if !highByte {
+		runes := make([]rune, len(data))
+		for idx, char := range data {
+			runes[idx] = rune(char)
+		}
+		return string(runes)
+	}
+	units := make([]uint16, len(data)/2)
+	for idx := range units {
+		units[idx] = binary.LittleEndian.Uint16(data[idx*2:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// xlsSegments reads data split over a record and its CONTINUE records.
+type xlsSegments struct {
+	segments [][]byte
+	segment  int
+	position int
+}
+
+var errXLSTruncated = errors.New("truncated shared strings")
+
+// next moves to the following segment when the current one is read up.
+func (s *xlsSegments) next() error {
+	for s.position >= len(s.segments[s.segment]) {
+		if s.segment+1 >= len(s.segments) {
+			return errXLSTruncated
+		}
+		s.segment++
+		s.position = 0
+	}
+	return nil
+}
+
+func (s *xlsSegments) read(size int) ([]byte, error) {
+	data := make([]byte, 0, size)
+	for len(data) < size {
+		if err := s.next(); err != nil {
+			return nil, err
+		}
+		available := min(size-len(data), len(s.segments[s.segment])-s.position)
+		data = append(data, s.segments[s.segment][s.position:s.position+available]...)
+		s.position += available
+	}
+	return data, nil
+}
+
+// chars reads count characters. When they continue in the next segment, it starts with a
+// flags byte telling again whether the rest is stored as UTF-16 or compressed.
+func (s *xlsSegments) chars(count int, highByte bool) (string, error) {
+	var text []byte
+	var decoded string
+	for count > 0 {
+		if s.position >= len(s.segments[s.segment]) {
+			if s.segment+1 >= len(s.segments) {
+				return "", errXLSTruncated
+			}
+			decoded += xlsChars(text, highByte)
+			text = nil
+			s.segment++
+			s.position = 0
+			flags, err := s.read(1)
+			if err != nil {
+				return "", err
+			}
+			highByte = flags[0]&0x01 != 0
+		}
+		charSize := 1
+		if highByte {
+			charSize = 2
+		}
+		available := min(count, (len(s.segments[s.segment])-s.position)/charSize)
+		if available == 0 {
+			return "", fmt.Errorf("character split across records")
+		}
+		text = append(text, s.segments[s.segment][s.position:s.position+available*charSize]...)
+		s.position += available * charSize
+		count -= available
+	}
+	return decoded + xlsChars(text, highByte), nil
+}
+
+// readXLSSharedStrings reads the shared string table. Rich text runs and phonetic data are
+// skipped: only the text of a string is kept.
+func readXLSSharedStrings(segments [][]byte) ([]string, error) {
+	table := &xlsSegments{segments: segments}
+	header, err := table.read(8)
+	if err != nil {
+		return nil, err
+	}
+	unique := int(binary.LittleEndian.Uint32(header[4:]))
+
+	strings := make([]string, 0, unique)
+	for len(strings) < unique {
+		stringHeader, err := table.read(3)
+		if err != nil {
+			return nil, err
+		}
+		count := int(binary.LittleEndian.Uint16(stringHeader))
+		flags := stringHeader[2]
+
+		runs, extension := 0, 0
+		if flags&0x08 != 0 {
+			runCount, err := table.read(2)
+			if err != nil {
+				return nil, err
+			}
+			runs = int(binary.LittleEndian.Uint16(runCount))
+		}
+		if flags&0x04 != 0 {
+			extensionSize, err := table.read(4)
+			if err != nil {
+				return nil, err
+			}
+			extension = int(binary.LittleEndian.Uint32(extensionSize))
+		}
+
+		value, err := table.chars(count, flags&0x01 != 0)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := table.read(runs*4 + extension); err != nil {
+			return nil, err
+		}
+		strings = append(strings, value)
+	}
+	return strings, nil
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+)
+
+const yearRangeCachedKey = "region_incomes_year_range"
+
+// yearRangeTTL bounds how long the year range is cached. Ingestion does not clear the cache, and
+// a stale range rejects every request for a newly loaded year, so it expires sooner than the
+// cached incomes.
+const yearRangeTTL = 5 * time.Minute
+
+func (r *SQLRepository) GetLoadedYearRange(ctx context.Context) (*domain.YearRange, error) {
+	yearRange := &domain.YearRange{}
+
+	query := `SELECT
+					COALESCE(MIN(year), 0) AS min_year,
+					COALESCE(MAX(year), 0) AS max_year
+				FROM region_incomes`
+
+	err := r.db.GetContext(ctx, yearRange, query)
+	if err != nil {
+		return nil, fmt.Errorf("err getting loaded year range: %w", classifyDBError(err))
+	}
+	if yearRange.MaxYear == 0 {
+		return nil, fmt.Errorf("no region incomes loaded: %w", domain.ErrNotFound)
+	}
+
+	return yearRange, nil
+}
+
+func (r *RedisRepository) GetCachedYearRange(ctx context.Context) (*domain.YearRange, error) {
+	var yearRangeJSON string
+
+	err := r.db.Get(ctx, yearRangeCachedKey).Scan(&yearRangeJSON)
+	if err != nil {
+		return nil, fmt.Errorf("error getting cached year range: %w", classifyRedisError(err))
+	}
+
+	yearRange := &domain.YearRange{}
+	err = json.Unmarshal([]byte(yearRangeJSON), yearRange)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling cached year range: %w", err)
+	}
+	return yearRange, nil
+}
+
+func (r *RedisRepository) SetCachedYearRange(ctx context.Context, yearRange *domain.YearRange) error {
+	yearRangeJSON, err := json.Marshal(yearRange)
+	if err != nil {
+		return fmt.Errorf("error marshalling cached year range: %w", err)
+	}
+
+	err = r.db.Set(ctx, yearRangeCachedKey, yearRangeJSON, min(r.ttl, yearRangeTTL)).Err()
+	if err != nil {
+		return fmt.Errorf("error setting cached year range: %w", classifyRedisError(err))
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Official region codes: OKATO (11 digits), OKTMO (8 digits) and ISO 3166-2:RU.
+-- Regions that ISO 3166-2:RU does not list have no ISO code.
+ALTER TABLE regions
+    ADD COLUMN IF NOT EXISTS okato_code VARCHAR(11),
+    ADD COLUMN IF NOT EXISTS oktmo_code VARCHAR(8),
+    ADD COLUMN IF NOT EXISTS iso_code VARCHAR(6);
+
+UPDATE regions
+SET okato_code = codes.okato_code,
+    oktmo_code = codes.oktmo_code,
+    iso_code = codes.iso_code
+FROM (VALUES
+(1, '79000000000', '79000000', 'RU-AD'),
+(2, '80000000000', '80000000', 'RU-BA'),
+(3, '81000000000', '81000000', 'RU-BU'),
+(4, '84000000000', '84000000', 'RU-AL'),
+(5, '82000000000', '82000000', 'RU-DA'),
+(6, '26000000000', '26000000', 'RU-IN'),
+(7, '83000000000', '83000000', 'RU-KB'),
+(8, '85000000000', '85000000', 'RU-KL'),
+(9, '91000000000', '91000000', 'RU-KC'),
+(10, '86000000000', '86000000', 'RU-KR'),
+(11, '87000000000', '87000000', 'RU-KO'),
+(12, '88000000000', '88000000', 'RU-ME'),
+(13, '89000000000', '89000000', 'RU-MO'),
+(14, '98000000000', '98000000', 'RU-SA'),
+(15, '90000000000', '90000000', 'RU-SE'),
+(16, '92000000000', '92000000', 'RU-TA'),
+(17, '93000000000', '93000000', 'RU-TY'),
+(18, '94000000000', '94000000', 'RU-UD'),
+(19, '95000000000', '95000000', 'RU-KK'),
+(20, '96000000000', '96000000', 'RU-CE'),
+(21, '97000000000', '97000000', 'RU-CU'),
+(22, '01000000000', '01000000', 'RU-ALT'),
+(23, '03000000000', '03000000', 'RU-KDA'),
+(24, '04000000000', '04000000', 'RU-KYA'),
+(25, '05000000000', '05000000', 'RU-PRI'),
+(26, '07000000000', '07000000', 'RU-STA'),
+(27, '08000000000', '08000000', 'RU-KHA'),
+(28, '10000000000', '10000000', 'RU-AMU'),
+(29, '11000000000', '11000000', 'RU-ARK'),
+(30, '12000000000', '12000000', 'RU-AST'),
+(31, '14000000000', '14000000', 'RU-BEL'),
+(32, '15000000000', '15000000', 'RU-BRY'),
+(33, '17000000000', '17000000', 'RU-VLA'),
+(34, '18000000000', '18000000', 'RU-VGG'),
+(35, '19000000000', '19000000', 'RU-VLG'),
+(36, '20000000000', '20000000', 'RU-VOR'),
+(37, '24000000000', '24000000', 'RU-IVA'),
+(38, '25000000000', '25000000', 'RU-IRK'),
+(39, '27000000000', '27000000', 'RU-KGD'),
+(40, '29000000000', '29000000', 'RU-KLU'),
+(41, '30000000000', '30000000', 'RU-KAM'),
+(42, '32000000000', '32000000', 'RU-KEM'),
+(43, '33000000000', '33000000', 'RU-KIR'),
+(44, '34000000000', '34000000', 'RU-KOS'),
+(45, '37000000000', '37000000', 'RU-KGN'),
+(46, '38000000000', '38000000', 'RU-KRS'),
+(47, '41000000000', '41000000', 'RU-LEN'),
+(48, '42000000000', '42000000', 'RU-LIP'),
+(49, '44000000000', '44000000', 'RU-MAG'),
+(50, '46000000000', '46000000', 'RU-MOS'),
+(51, '47000000000', '47000000', 'RU-MUR'),
+(52, '22000000000', '22000000', 'RU-NIZ'),
+(53, '49000000000', '49000000', 'RU-NGR'),
+(54, '50000000000', '50000000', 'RU-NVS'),
+(55, '52000000000', '52000000', 'RU-OMS'),
+(56, '53000000000', '53000000', 'RU-ORE'),
+(57, '54000000000', '54000000', 'RU-ORL'),
+(58, '56000000000', '56000000', 'RU-PNZ'),
+(59, '57000000000', '57000000', 'RU-PER'),
+(60, '58000000000', '58000000', 'RU-PSK'),
+(61, '60000000000', '60000000', 'RU-ROS'),
+(62, '61000000000', '61000000', 'RU-RYA'),
+(63, '36000000000', '36000000', 'RU-SAM'),
+(64, '63000000000', '63000000', 'RU-SAR'),
+(65, '64000000000', '64000000', 'RU-SAK'),
+(66, '65000000000', '65000000', 'RU-SVE'),
+(67, '66000000000', '66000000', 'RU-SMO'),
+(68, '68000000000', '68000000', 'RU-TAM'),
+(69, '28000000000', '28000000', 'RU-TVE'),
+(70, '69000000000', '69000000', 'RU-TOM'),
+(71, '70000000000', '70000000', 'RU-TUL'),
+(72, '71000000000', '71000000', 'RU-TYU'),
+(73, '73000000000', '73000000', 'RU-ULY'),
+(74, '75000000000', '75000000', 'RU-CHE'),
+(75, '76000000000', '76000000', 'RU-ZAB'),
+(76, '78000000000', '78000000', 'RU-YAR'),
+(77, '45000000000', '45000000', 'RU-MOW'),
+(78, '40000000000', '40000000', 'RU-SPE'),
+(79, '99000000000', '99000000', 'RU-YEV'),
+(83, '11100000000', '11800000', 'RU-NEN'),
+(86, '71100000000', '71800000', 'RU-KHM'),
+(87, '77000000000', '77000000', 'RU-CHU'),
+(89, '71140000000', '71900000', 'RU-YAN'),
+(90, '23000000000', '23000000', NULL),
+(91, '35000000000', '35000000', NULL),
+(92, '67000000000', '67000000', NULL),
+(93, '21000000000', '21000000', NULL),
+(94, '43000000000', '43000000', NULL),
+(95, '39000000000', '39000000', NULL),
+(99, '55000000000', '55000000', NULL)
+) AS codes (region_id, okato_code, oktmo_code, iso_code)
+WHERE regions.region_id = codes.region_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_regions_okato_code ON regions (okato_code);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_regions_oktmo_code ON regions (oktmo_code);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_regions_iso_code ON regions (iso_code);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_regions_okato_code;
+DROP INDEX IF EXISTS idx_regions_oktmo_code;
+DROP INDEX IF EXISTS idx_regions_iso_code;
+ALTER TABLE regions
+    DROP COLUMN IF EXISTS okato_code,
+    DROP COLUMN IF EXISTS oktmo_code,
+    DROP COLUMN IF EXISTS iso_code;
+-- +goose StatementEnd
\ No newline at end of file
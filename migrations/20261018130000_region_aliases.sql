@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS region_aliases (
+    id SERIAL PRIMARY KEY,
+    region_id INTEGER NOT NULL REFERENCES regions (region_id),
+    alias VARCHAR(256) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (alias)
+    );
+CREATE INDEX IF NOT EXISTS idx_region_aliases_region_id ON region_aliases (region_id);
+
+-- Известные варианты написания в файлах Росстата
+INSERT INTO region_aliases (region_id, alias) VALUES
+(21, 'Чувашская Республика - Чувашия'),
+(42, 'Кемеровская область - Кузбасс'),
+(77, 'Москва'),
+(78, 'Санкт-Петербург'),
+(79, 'Еврейская автономная область'),
+(83, 'Ненецкий автономный округ'),
+(86, 'Ханты-Мансийский автономный округ - Югра'),
+(87, 'Чукотский автономный округ'),
+(89, 'Ямало-Ненецкий автономный округ'),
+(92, 'Севастополь')
+ON CONFLICT (alias) DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_region_aliases_region_id;
+DROP TABLE IF EXISTS region_aliases;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS ingestion_rejects (
+    id BIGSERIAL PRIMARY KEY,
+    source_file VARCHAR(512) NOT NULL,
+    sheet VARCHAR(128) NOT NULL,
+    row_number INTEGER NOT NULL,
+    header TEXT[] NOT NULL,
+    cells TEXT[] NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    CONSTRAINT UQ_IngestionRejects UNIQUE (source_file, sheet, row_number)
+    );
+CREATE INDEX IF NOT EXISTS idx_ingestion_rejects_open ON ingestion_rejects (id) WHERE resolved_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_ingestion_rejects_open;
+DROP TABLE IF EXISTS ingestion_rejects;
+-- +goose StatementEnd
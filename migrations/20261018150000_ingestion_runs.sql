@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS ingestion_runs (
+    id BIGSERIAL PRIMARY KEY,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP,
+    source_url VARCHAR(1024) NOT NULL DEFAULT '',
+    local_path VARCHAR(512) NOT NULL,
+    file_sha256 VARCHAR(64) NOT NULL DEFAULT '',
+    sheet_count INTEGER NOT NULL DEFAULT 0,
+    rows_parsed INTEGER NOT NULL DEFAULT 0,
+    rows_inserted BIGINT NOT NULL DEFAULT 0,
+    rows_rejected INTEGER NOT NULL DEFAULT 0,
+    unmatched_regions TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(16) NOT NULL,
+    error TEXT NOT NULL DEFAULT ''
+    );
+CREATE INDEX IF NOT EXISTS idx_ingestion_runs_started_at ON ingestion_runs (started_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_ingestion_runs_started_at;
+DROP TABLE IF EXISTS ingestion_runs;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ingestion_runs
+    ADD COLUMN IF NOT EXISTS etag VARCHAR(256) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS last_modified VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS skip_reason TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ingestion_runs
+    DROP COLUMN IF EXISTS skip_reason,
+    DROP COLUMN IF EXISTS last_modified,
+    DROP COLUMN IF EXISTS etag;
+-- +goose StatementEnd
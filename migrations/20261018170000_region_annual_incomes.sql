@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS region_annual_incomes (
+    id SERIAL PRIMARY KEY,
+    region_id INTEGER NOT NULL,
+    year INTEGER NOT NULL,
+    value DECIMAL(18,2) NOT NULL,
+    loaded_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT CHK_AnnualValueNonNegative CHECK (value >= 0),
+    CONSTRAINT UQ_RegionAnnualIncomes UNIQUE (region_id, year, value)
+    );
+CREATE INDEX IF NOT EXISTS idx_region_annual_incomes_region_year ON region_annual_incomes (region_id, year);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_region_annual_incomes_region_year;
+DROP TABLE IF EXISTS region_annual_incomes;
+-- +goose StatementEnd
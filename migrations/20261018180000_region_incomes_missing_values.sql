@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE region_incomes ALTER COLUMN value DROP NOT NULL;
+ALTER TABLE region_incomes ADD COLUMN IF NOT EXISTS missing_marker VARCHAR(16);
+-- Empty cells used to be stored as a zero income.
+UPDATE region_incomes SET value = NULL, missing_marker = '' WHERE value = 0;
+ALTER TABLE region_incomes ADD CONSTRAINT CHK_ValueOrMissingMarker CHECK ((value IS NULL) = (missing_marker IS NOT NULL));
+ALTER TABLE region_incomes DROP CONSTRAINT IF EXISTS UQ_RegionIncomes;
+ALTER TABLE region_incomes ADD CONSTRAINT UQ_RegionIncomes UNIQUE NULLS NOT DISTINCT (region_id, year, quarter, value);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE region_incomes DROP CONSTRAINT IF EXISTS UQ_RegionIncomes;
+ALTER TABLE region_incomes DROP CONSTRAINT IF EXISTS CHK_ValueOrMissingMarker;
+DELETE FROM region_incomes missing
+WHERE missing.value IS NULL
+  AND EXISTS (
+    SELECT 1 FROM region_incomes zero
+    WHERE zero.region_id = missing.region_id
+      AND zero.year = missing.year
+      AND zero.quarter = missing.quarter
+      AND zero.value = 0
+  );
+UPDATE region_incomes SET value = 0 WHERE value IS NULL;
+ALTER TABLE region_incomes DROP COLUMN IF EXISTS missing_marker;
+ALTER TABLE region_incomes ALTER COLUMN value SET NOT NULL;
+ALTER TABLE region_incomes ADD CONSTRAINT UQ_RegionIncomes UNIQUE (region_id, year, quarter, value);
+-- +goose StatementEnd
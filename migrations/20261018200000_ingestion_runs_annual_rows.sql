@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ingestion_runs
+    ADD COLUMN IF NOT EXISTS annual_rows_inserted BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ingestion_runs
+    DROP COLUMN IF EXISTS annual_rows_inserted;
+-- +goose StatementEnd
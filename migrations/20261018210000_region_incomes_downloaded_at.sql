@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- downloaded_at is when the source file of a value was downloaded; it orders the values of a
+-- period, so that loading an older file later does not replace newer figures.
+ALTER TABLE region_incomes ADD COLUMN IF NOT EXISTS downloaded_at TIMESTAMP;
+UPDATE region_incomes SET downloaded_at = loaded_at WHERE downloaded_at IS NULL;
+ALTER TABLE region_incomes ALTER COLUMN downloaded_at SET NOT NULL;
+ALTER TABLE region_incomes ALTER COLUMN downloaded_at SET DEFAULT NOW();
+
+ALTER TABLE region_annual_incomes ADD COLUMN IF NOT EXISTS downloaded_at TIMESTAMP;
+UPDATE region_annual_incomes SET downloaded_at = loaded_at WHERE downloaded_at IS NULL;
+ALTER TABLE region_annual_incomes ALTER COLUMN downloaded_at SET NOT NULL;
+ALTER TABLE region_annual_incomes ALTER COLUMN downloaded_at SET DEFAULT NOW();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE region_annual_incomes DROP COLUMN IF EXISTS downloaded_at;
+ALTER TABLE region_incomes DROP COLUMN IF EXISTS downloaded_at;
+-- +goose StatementEnd
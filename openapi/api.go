@@ -20,6 +20,25 @@ import (
 // pass the data to a GetRegionIncomesAPIServicer to perform the required actions, then write the service results to the http response.
 type GetRegionIncomesAPIRouter interface {
 	GetRegionIncomes(http.ResponseWriter, *http.Request)
+	GetRegionIncomesV2(http.ResponseWriter, *http.Request)
+	GetRegionQuarterIncomes(http.ResponseWriter, *http.Request)
+	GetRegionAnnualIncomes(http.ResponseWriter, *http.Request)
+}
+
+// IngestionAPIRouter defines the required methods for binding the api requests to a responses for the IngestionAPI
+// The IngestionAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a IngestionAPIServicer to perform the required actions, then write the service results to the http response.
+type IngestionAPIRouter interface {
+	GetIngestionRejects(http.ResponseWriter, *http.Request)
+	GetIngestionRuns(http.ResponseWriter, *http.Request)
+}
+
+// RegionsAPIRouter defines the required methods for binding the api requests to a responses for the RegionsAPI
+// The RegionsAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a RegionsAPIServicer to perform the required actions, then write the service results to the http response.
+type RegionsAPIRouter interface {
+	GetRegion(http.ResponseWriter, *http.Request)
+	GetRegions(http.ResponseWriter, *http.Request)
 }
 
 // GetRegionIncomesAPIServicer defines the api actions for the GetRegionIncomesAPI service
@@ -27,5 +46,26 @@ type GetRegionIncomesAPIRouter interface {
 // while the service implementation can be ignored with the .openapi-generator-ignore file
 // and updated with the logic required for the API.
 type GetRegionIncomesAPIServicer interface {
-	GetRegionIncomes(context.Context, int32, int32, int32) (ImplResponse, error)
+	GetRegionIncomes(context.Context, []int32, []string, []string, int32, int32, string, int32, string) (ImplResponse, error)
+	GetRegionIncomesV2(context.Context, []int32, []string, []string, int32, int32, string, int32, string) (ImplResponse, error)
+	GetRegionQuarterIncomes(context.Context, int32, string, string) (ImplResponse, error)
+	GetRegionAnnualIncomes(context.Context, int32, int32, int32) (ImplResponse, error)
+}
+
+// IngestionAPIServicer defines the api actions for the IngestionAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type IngestionAPIServicer interface {
+	GetIngestionRejects(context.Context, string, int32) (ImplResponse, error)
+	GetIngestionRuns(context.Context, int32) (ImplResponse, error)
+}
+
+// RegionsAPIServicer defines the api actions for the RegionsAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type RegionsAPIServicer interface {
+	GetRegion(context.Context, int32) (ImplResponse, error)
+	GetRegions(context.Context, string) (ImplResponse, error)
 }
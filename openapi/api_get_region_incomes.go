@@ -13,6 +13,8 @@ package openapi
 import (
 	"net/http"
 	"strings"
+
+	"github.com/gorilla/mux"
 )
 
 // GetRegionIncomesAPIController binds http requests to an api service and writes the service results to the http response
@@ -53,6 +55,21 @@ func (c *GetRegionIncomesAPIController) Routes() Routes {
 			"/api/v1/regionincomes",
 			c.GetRegionIncomes,
 		},
+		"GetRegionIncomesV2": Route{
+			strings.ToUpper("Get"),
+			"/api/v2/regionincomes",
+			c.GetRegionIncomesV2,
+		},
+		"GetRegionQuarterIncomes": Route{
+			strings.ToUpper("Get"),
+			"/api/v1/regions/{id}/incomes",
+			c.GetRegionQuarterIncomes,
+		},
+		"GetRegionAnnualIncomes": Route{
+			strings.ToUpper("Get"),
+			"/api/v1/regions/{id}/annualincomes",
+			c.GetRegionAnnualIncomes,
+		},
 	}
 }
 
@@ -63,11 +80,123 @@ func (c *GetRegionIncomesAPIController) GetRegionIncomes(w http.ResponseWriter,
 		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
 		return
 	}
-	var regionidParam int32
+	var regionidParam []int32
 	if query.Has("regionid") {
+		param, err := parseNumericArrayParameter[int32](
+			strings.Join(query["regionid"], ","), ",", false,
+			WithRequire[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "regionid", Err: err}, nil)
+			return
+		}
+
+		regionidParam = param
+	} else {
+	}
+	var regioncodeParam []string
+	if query.Has("regioncode") {
+		param := strings.Split(strings.Join(query["regioncode"], ","), ",")
+
+		regioncodeParam = param
+	} else {
+	}
+	var regionnameParam []string
+	if query.Has("regionname") {
+		param := query["regionname"]
+
+		regionnameParam = param
+	} else {
+	}
+	var yearParam int32
+	if query.Has("year") {
+		param, err := parseNumericParameter[int32](
+			query.Get("year"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "year", Err: err}, nil)
+			return
+		}
+
+		yearParam = param
+	} else {
+	}
+	var quarterParam int32
+	if query.Has("quarter") {
 		param, err := parseNumericParameter[int32](
-			query.Get("regionid"),
+			query.Get("quarter"),
 			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+			WithMaximum[int32](4),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "quarter", Err: err}, nil)
+			return
+		}
+
+		quarterParam = param
+	} else {
+	}
+	var modeParam string
+	if query.Has("mode") {
+		param := query.Get("mode")
+
+		modeParam = param
+	} else {
+		param := "trailing"
+		modeParam = param
+	}
+	var windowParam int32
+	if query.Has("window") {
+		param, err := parseNumericParameter[int32](
+			query.Get("window"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+			WithMaximum[int32](40),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "window", Err: err}, nil)
+			return
+		}
+
+		windowParam = param
+	} else {
+		var param int32 = 4
+		windowParam = param
+	}
+	var methodParam string
+	if query.Has("method") {
+		param := query.Get("method")
+
+		methodParam = param
+	} else {
+		param := "mean"
+		methodParam = param
+	}
+	result, err := c.service.GetRegionIncomes(r.Context(), regionidParam, regioncodeParam, regionnameParam, yearParam, quarterParam, modeParam, windowParam, methodParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetRegionIncomesV2 - Get average region incomes (v2)
+func (c *GetRegionIncomesAPIController) GetRegionIncomesV2(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var regionidParam []int32
+	if query.Has("regionid") {
+		param, err := parseNumericArrayParameter[int32](
+			strings.Join(query["regionid"], ","), ",", false,
+			WithRequire[int32](parseInt32),
 		)
 		if err != nil {
 			c.errorHandler(w, r, &ParsingError{Param: "regionid", Err: err}, nil)
@@ -76,14 +205,27 @@ func (c *GetRegionIncomesAPIController) GetRegionIncomes(w http.ResponseWriter,
 
 		regionidParam = param
 	} else {
-		c.errorHandler(w, r, &RequiredError{Field: "regionid"}, nil)
-		return
+	}
+	var regioncodeParam []string
+	if query.Has("regioncode") {
+		param := strings.Split(strings.Join(query["regioncode"], ","), ",")
+
+		regioncodeParam = param
+	} else {
+	}
+	var regionnameParam []string
+	if query.Has("regionname") {
+		param := query["regionname"]
+
+		regionnameParam = param
+	} else {
 	}
 	var yearParam int32
 	if query.Has("year") {
 		param, err := parseNumericParameter[int32](
 			query.Get("year"),
 			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
 		)
 		if err != nil {
 			c.errorHandler(w, r, &ParsingError{Param: "year", Err: err}, nil)
@@ -98,6 +240,8 @@ func (c *GetRegionIncomesAPIController) GetRegionIncomes(w http.ResponseWriter,
 		param, err := parseNumericParameter[int32](
 			query.Get("quarter"),
 			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+			WithMaximum[int32](4),
 		)
 		if err != nil {
 			c.errorHandler(w, r, &ParsingError{Param: "quarter", Err: err}, nil)
@@ -107,7 +251,139 @@ func (c *GetRegionIncomesAPIController) GetRegionIncomes(w http.ResponseWriter,
 		quarterParam = param
 	} else {
 	}
-	result, err := c.service.GetRegionIncomes(r.Context(), regionidParam, yearParam, quarterParam)
+	var modeParam string
+	if query.Has("mode") {
+		param := query.Get("mode")
+
+		modeParam = param
+	} else {
+		param := "trailing"
+		modeParam = param
+	}
+	var windowParam int32
+	if query.Has("window") {
+		param, err := parseNumericParameter[int32](
+			query.Get("window"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+			WithMaximum[int32](40),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "window", Err: err}, nil)
+			return
+		}
+
+		windowParam = param
+	} else {
+		var param int32 = 4
+		windowParam = param
+	}
+	var methodParam string
+	if query.Has("method") {
+		param := query.Get("method")
+
+		methodParam = param
+	} else {
+		param := "mean"
+		methodParam = param
+	}
+	result, err := c.service.GetRegionIncomesV2(r.Context(), regionidParam, regioncodeParam, regionnameParam, yearParam, quarterParam, modeParam, windowParam, methodParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetRegionQuarterIncomes - Get quarterly region incomes
+func (c *GetRegionIncomesAPIController) GetRegionQuarterIncomes(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	idParam, err := parseNumericParameter[int32](
+		params["id"],
+		WithRequire[int32](parseInt32),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Param: "id", Err: err}, nil)
+		return
+	}
+	var fromParam string
+	if query.Has("from") {
+		param := query.Get("from")
+
+		fromParam = param
+	} else {
+	}
+	var toParam string
+	if query.Has("to") {
+		param := query.Get("to")
+
+		toParam = param
+	} else {
+	}
+	result, err := c.service.GetRegionQuarterIncomes(r.Context(), idParam, fromParam, toParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetRegionAnnualIncomes - Get annual region incomes
+func (c *GetRegionIncomesAPIController) GetRegionAnnualIncomes(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	idParam, err := parseNumericParameter[int32](
+		params["id"],
+		WithRequire[int32](parseInt32),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Param: "id", Err: err}, nil)
+		return
+	}
+	var fromParam int32
+	if query.Has("from") {
+		param, err := parseNumericParameter[int32](
+			query.Get("from"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "from", Err: err}, nil)
+			return
+		}
+
+		fromParam = param
+	} else {
+	}
+	var toParam int32
+	if query.Has("to") {
+		param, err := parseNumericParameter[int32](
+			query.Get("to"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "to", Err: err}, nil)
+			return
+		}
+
+		toParam = param
+	} else {
+	}
+	result, err := c.service.GetRegionAnnualIncomes(r.Context(), idParam, fromParam, toParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -12,45 +12,194 @@ package openapi
 
 import (
 	"context"
+	"fmt"
 	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
 	"log/slog"
 	"net/http"
 )
 type AverageRegionIncomeProcessor interface {
-	GetRegionIncomes(ctx context.Context, regionId int32, year int32, quarter int32) (*domain.AverageRegionIncomes, error)
+	GetRegionIncomes(ctx context.Context, regionIds []int32, year int32, quarter int32, averaging domain.Averaging) ([]*domain.AverageRegionIncomes, error)
+	GetRegionQuarterIncomes(ctx context.Context, regionId int32, from domain.YearQuarter, to domain.YearQuarter) ([]*domain.RegionQuarterIncome, error)
+	GetRegionAnnualIncomes(ctx context.Context, regionId int32, fromYear int32, toYear int32) ([]*domain.RegionAnnualIncome, error)
+}
+type RegionResolver interface {
+	ResolveRegionIds(ctx context.Context, codes []string, names []string) ([]int32, error)
 }
 // GetRegionIncomesAPIService is a service that implements the logic for the GetRegionIncomesAPIServicer
 // This service should implement the business logic for every endpoint for the GetRegionIncomesAPI API.
 // Include any external packages or services that will be required by this service.
 type GetRegionIncomesAPIService struct {
 	regionIncomesProcessor AverageRegionIncomeProcessor
+	regionResolver RegionResolver
+	moneyFormat domain.MoneyFormat
 	log *slog.Logger
 }
 
 // NewGetRegionIncomesAPIService creates a default api service
-func NewGetRegionIncomesAPIService(averageRegionIncomeProcessor AverageRegionIncomeProcessor, log *slog.Logger ) *GetRegionIncomesAPIService {
+func NewGetRegionIncomesAPIService(averageRegionIncomeProcessor AverageRegionIncomeProcessor, regionResolver RegionResolver, moneyFormat domain.MoneyFormat, log *slog.Logger ) *GetRegionIncomesAPIService {
 	return &GetRegionIncomesAPIService{
 		regionIncomesProcessor: averageRegionIncomeProcessor,
+		regionResolver: regionResolver,
+		moneyFormat: moneyFormat,
 		log: log,
 	}
 }
 
 // GetRegionIncomes - Get average region incomes
-func (s *GetRegionIncomesAPIService) GetRegionIncomes(ctx context.Context, regionid int32, year int32, quarter int32) (ImplResponse, error) {
-	ri, err := s.regionIncomesProcessor.GetRegionIncomes(ctx, regionid, year, quarter)
+func (s *GetRegionIncomesAPIService) GetRegionIncomes(ctx context.Context, regionid []int32, regioncode []string, regionname []string, year int32, quarter int32, mode string, window int32, method string) (ImplResponse, error) {
+	ri, err := s.getRegionIncomes(ctx, regionid, regioncode, regionname, year, quarter, mode, window, method)
+	if err != nil {
+		return Response(errorStatusCode(err), nil), err
+	}
+	openApiRegionIncomes := make([]Averageregionincomes, 0, len(ri))
+	for _, regionIncomes := range ri {
+		openApiRegionIncomes = append(openApiRegionIncomes, domainRegionIncomesToOpenApi(regionIncomes, s.moneyFormat))
+	}
+	return Response(http.StatusOK, openApiRegionIncomes), nil
+}
+
+// GetRegionIncomesV2 - Get average region incomes (v2)
+func (s *GetRegionIncomesAPIService) GetRegionIncomesV2(ctx context.Context, regionid []int32, regioncode []string, regionname []string, year int32, quarter int32, mode string, window int32, method string) (ImplResponse, error) {
+	ri, err := s.getRegionIncomes(ctx, regionid, regioncode, regionname, year, quarter, mode, window, method)
 	if err != nil {
-		return Response(http.StatusInternalServerError, nil), err
+		return Response(errorStatusCode(err), nil), err
+	}
+	openApiRegionIncomes := make([]AverageregionincomesV2, 0, len(ri))
+	for _, regionIncomes := range ri {
+		openApiRegionIncomes = append(openApiRegionIncomes, domainRegionIncomesToOpenApiV2(regionIncomes, s.moneyFormat))
 	}
-	openApiRegionIncomes := domainRegionIncomesToOpenApi(ri)
 	return Response(http.StatusOK, openApiRegionIncomes), nil
 }
 
-func domainRegionIncomesToOpenApi(domainRegionIncomes *domain.AverageRegionIncomes) Averageregionincomes  {
+// getRegionIncomes resolves the region identifiers and parses the averaging parameters shared by every
+// version of the endpoint, then queries the processor.
+func (s *GetRegionIncomesAPIService) getRegionIncomes(ctx context.Context, regionid []int32, regioncode []string, regionname []string, year int32, quarter int32, mode string, window int32, method string) ([]*domain.AverageRegionIncomes, error) {
+	averagingMode, err := domain.ParseAveragingMode(mode)
+	if err != nil {
+		return nil, &ParsingError{Param: "mode", Err: err}
+	}
+	averagingMethod, err := domain.ParseAveragingMethod(method)
+	if err != nil {
+		return nil, &ParsingError{Param: "method", Err: err}
+	}
+	averaging := domain.Averaging{Mode: averagingMode, Window: window, Method: averagingMethod}
+	resolvedRegionIds, err := s.regionResolver.ResolveRegionIds(ctx, regioncode, regionname)
+	if err != nil {
+		return nil, err
+	}
+	return s.regionIncomesProcessor.GetRegionIncomes(ctx, append(regionid, resolvedRegionIds...), year, quarter, averaging)
+}
+
+// GetRegionQuarterIncomes - Get quarterly region incomes
+func (s *GetRegionIncomesAPIService) GetRegionQuarterIncomes(ctx context.Context, id int32, from string, to string) (ImplResponse, error) {
+	fromPeriod, toPeriod, err := parsePeriodRange(from, to)
+	if err != nil {
+		return Response(http.StatusBadRequest, nil), err
+	}
+	qi, err := s.regionIncomesProcessor.GetRegionQuarterIncomes(ctx, id, fromPeriod, toPeriod)
+	if err != nil {
+		return Response(errorStatusCode(err), nil), err
+	}
+	openApiQuarterIncomes := make([]Regionquarterincome, 0, len(qi))
+	for _, quarterIncome := range qi {
+		openApiQuarterIncomes = append(openApiQuarterIncomes, domainQuarterIncomeToOpenApi(quarterIncome, s.moneyFormat))
+	}
+	return Response(http.StatusOK, openApiQuarterIncomes), nil
+}
+
+// GetRegionAnnualIncomes - Get annual region incomes
+func (s *GetRegionIncomesAPIService) GetRegionAnnualIncomes(ctx context.Context, id int32, from int32, to int32) (ImplResponse, error) {
+	if from != 0 && to != 0 && to < from {
+		return Response(http.StatusBadRequest, nil), &ParsingError{Param: "to", Err: fmt.Errorf("%w: year [%d] is before from [%d]", domain.ErrInvalidPeriod, to, from)}
+	}
+	ai, err := s.regionIncomesProcessor.GetRegionAnnualIncomes(ctx, id, from, to)
+	if err != nil {
+		return Response(errorStatusCode(err), nil), err
+	}
+	openApiAnnualIncomes := make([]Regionannualincome, 0, len(ai))
+	for _, annualIncome := range ai {
+		openApiAnnualIncomes = append(openApiAnnualIncomes, domainAnnualIncomeToOpenApi(annualIncome, s.moneyFormat))
+	}
+	return Response(http.StatusOK, openApiAnnualIncomes), nil
+}
+
+// parsePeriodRange parses optional "YYYY.Q" bounds; an empty bound stays zero, meaning open.
+func parsePeriodRange(from string, to string) (domain.YearQuarter, domain.YearQuarter, error) {
+	var fromPeriod, toPeriod domain.YearQuarter
+	var err error
+	if from != "" {
+		fromPeriod, err = domain.ParseYearQuarter(from)
+		if err != nil {
+			return fromPeriod, toPeriod, &ParsingError{Param: "from", Err: err}
+		}
+	}
+	if to != "" {
+		toPeriod, err = domain.ParseYearQuarter(to)
+		if err != nil {
+			return fromPeriod, toPeriod, &ParsingError{Param: "to", Err: err}
+		}
+	}
+	if !fromPeriod.IsZero() && !toPeriod.IsZero() && toPeriod.Before(fromPeriod) {
+		return fromPeriod, toPeriod, &ParsingError{Param: "to", Err: fmt.Errorf("%w: period [%s] is before from [%s]", domain.ErrInvalidPeriod, toPeriod, fromPeriod)}
+	}
+	return fromPeriod, toPeriod, nil
+}
+
+func domainQuarterIncomeToOpenApi(domainQuarterIncome *domain.RegionQuarterIncome, moneyFormat domain.MoneyFormat) Regionquarterincome {
+	return Regionquarterincome{
+		RegionId: domainQuarterIncome.RegionId,
+		Year:     domainQuarterIncome.Year,
+		Quarter:  domainQuarterIncome.Quarter,
+		Value:    NewNullDecimal(domainQuarterIncome.Value, moneyFormat),
+		LoadedAt: domainQuarterIncome.LoadedAt,
+	}
+}
+
+func domainAnnualIncomeToOpenApi(domainAnnualIncome *domain.RegionAnnualIncome, moneyFormat domain.MoneyFormat) Regionannualincome {
+	return Regionannualincome{
+		RegionId: domainAnnualIncome.RegionId,
+		Year:     domainAnnualIncome.Year,
+		Value:    NewDecimal(domainAnnualIncome.Value, moneyFormat),
+		LoadedAt: domainAnnualIncome.LoadedAt,
+	}
+}
+
+func domainRegionIncomesToOpenApi(domainRegionIncomes *domain.AverageRegionIncomes, moneyFormat domain.MoneyFormat) Averageregionincomes  {
 	return Averageregionincomes{
 		RegionId: domainRegionIncomes.RegionId,
 		Year: domainRegionIncomes.Year,
 		Quarter: domainRegionIncomes.Quarter,
 		RegionName: domainRegionIncomes.RegionName,
-		AverageRegionIncomes: domainRegionIncomes.AverageRegionIncomes,
+		AverageRegionIncomes: NewDecimal(domainRegionIncomes.AverageRegionIncomes, moneyFormat),
+		FirstYear: domainRegionIncomes.FirstYear,
+		FirstQuarter: domainRegionIncomes.FirstQuarter,
+		LastYear: domainRegionIncomes.LastYear,
+		LastQuarter: domainRegionIncomes.LastQuarter,
+		QuartersCount: domainRegionIncomes.QuartersCount,
+		Complete: domainRegionIncomes.Complete,
+		LoadedAt: domainRegionIncomes.LoadedAt,
+	}
+}
+
+// domainRegionIncomesToOpenApiV2 maps a domain average to the v2 contract, where a quarter of 0
+// (calendar mode) is sent as null instead of being omitted.
+func domainRegionIncomesToOpenApiV2(domainRegionIncomes *domain.AverageRegionIncomes, moneyFormat domain.MoneyFormat) AverageregionincomesV2 {
+	var quarter *int32
+	if domainRegionIncomes.Quarter != 0 {
+		quarter = &domainRegionIncomes.Quarter
+	}
+	return AverageregionincomesV2{
+		RegionId: domainRegionIncomes.RegionId,
+		RegionName: domainRegionIncomes.RegionName,
+		Year: domainRegionIncomes.Year,
+		Quarter: quarter,
+		AverageRegionIncomes: NewDecimal(domainRegionIncomes.AverageRegionIncomes, moneyFormat),
+		FirstYear: domainRegionIncomes.FirstYear,
+		FirstQuarter: domainRegionIncomes.FirstQuarter,
+		LastYear: domainRegionIncomes.LastYear,
+		LastQuarter: domainRegionIncomes.LastQuarter,
+		QuartersCount: domainRegionIncomes.QuartersCount,
+		Complete: domainRegionIncomes.Complete,
+		LoadedAt: domainRegionIncomes.LoadedAt,
 	}
 }
@@ -0,0 +1,55 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// Decimal is an exact money value with a fixed number of decimal places. It is written as a JSON
+// string when Quoted is set and as a JSON number otherwise, without passing through a float.
+type Decimal struct {
+	Value  string
+	Quoted bool
+}
+
+// NewDecimal rounds d according to the money format and renders it with the format's scale
+func NewDecimal(d decimal.Decimal, moneyFormat domain.MoneyFormat) Decimal {
+	return Decimal{
+		Value:  moneyFormat.Format(d),
+		Quoted: moneyFormat.AsString,
+	}
+}
+
+// NewNullDecimal is NewDecimal for a value that may be absent; an absent value is written as null.
+func NewNullDecimal(d decimal.NullDecimal, moneyFormat domain.MoneyFormat) Decimal {
+	if !d.Valid {
+		return Decimal{}
+	}
+	return NewDecimal(d.Decimal, moneyFormat)
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if d.Value == "" {
+		return []byte("null"), nil
+	}
+	if d.Quoted {
+		return json.Marshal(d.Value)
+	}
+	return []byte(d.Value), nil
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+	var value decimal.Decimal
+	if err := value.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*d = Decimal{Value: value.String(), Quoted: len(data) > 0 && data[0] == '"'}
+	return nil
+}
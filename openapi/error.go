@@ -13,7 +13,11 @@ package openapi
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/middleware"
 )
 
 var (
@@ -52,23 +56,128 @@ func (e *RequiredError) Error() string {
 // you would like errors to be handled differently from the DefaultErrorHandler
 type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error, result *ImplResponse)
 
-// DefaultErrorHandler defines the default logic on how to handle errors from the controller. Any errors from parsing
-// request params will return a StatusBadRequest. Otherwise, the error code originating from the servicer will be used.
-func DefaultErrorHandler(w http.ResponseWriter, _ *http.Request, err error, result *ImplResponse) {
+// problemType is a stable RFC 7807 problem type with its human-readable title.
+type problemType struct {
+	URI   string
+	Title string
+}
+
+var (
+	problemInvalidParameter    = problemType{URI: "/problems/invalid-parameter", Title: "Invalid parameter"}
+	problemInvalidPeriod       = problemType{URI: "/problems/invalid-period", Title: "Invalid period"}
+	problemRequiredParameter   = problemType{URI: "/problems/required-parameter", Title: "Required parameter is missing"}
+	problemNotFound            = problemType{URI: "/problems/not-found", Title: "Not found"}
+	problemUpstreamUnavailable = problemType{URI: "/problems/upstream-unavailable", Title: "Service temporarily unavailable"}
+	problemInternal            = problemType{URI: "/problems/internal-error", Title: "Internal server error"}
+)
+
+// DefaultErrorHandler defines the default logic on how to handle errors from the controller, logging with the
+// default logger. See NewErrorHandler.
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error, result *ImplResponse) {
+	NewErrorHandler(slog.Default())(w, r, err, result)
+}
+
+// NewErrorHandler returns an ErrorHandler that logs with the given logger, so that failed requests are logged by
+// the same handler and with the same attributes as the rest of the service. Every error is written as an
+// application/problem+json document. Client errors (parsing, missing or invalid parameters) carry the error
+// text as detail; for all other errors the detail is generic and the error itself is only logged.
+func NewErrorHandler(logger *slog.Logger) ErrorHandler {
+	return func(w http.ResponseWriter, r *http.Request, err error, result *ImplResponse) {
+		problem := newProblem(err, result)
+		problem.InvalidParams = invalidParams(err)
+		problem.Instance = r.URL.RequestURI()
+		requestID, _ := r.Context().Value(middleware.RequestIDCtxKey).(middleware.RequestID)
+		problem.RequestId = string(requestID)
+
+		level := slog.LevelWarn
+		if problem.Status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.Log(r.Context(), level, "request failed",
+			slog.String("err", err.Error()),
+			slog.Int("status", int(problem.Status)),
+			slog.String("type", problem.Type),
+			slog.String("request_id", problem.RequestId),
+		)
+
+		_ = EncodeProblemResponse(problem, w)
+	}
+}
+
+func newProblem(err error, result *ImplResponse) Problem {
 	var parsingErr *ParsingError
 	if ok := errors.As(err, &parsingErr); ok {
 		// Handle parsing errors
-		_ = EncodeJSONResponse(err.Error(), func(i int) *int { return &i }(http.StatusBadRequest), w)
-		return
+		if errors.Is(err, domain.ErrInvalidPeriod) {
+			return problemOf(problemInvalidPeriod, http.StatusBadRequest, err.Error())
+		}
+		return problemOf(problemInvalidParameter, http.StatusBadRequest, err.Error())
 	}
 
 	var requiredErr *RequiredError
 	if ok := errors.As(err, &requiredErr); ok {
 		// Handle missing required errors
-		_ = EncodeJSONResponse(err.Error(), func(i int) *int { return &i }(http.StatusUnprocessableEntity), w)
-		return
+		return problemOf(problemRequiredParameter, http.StatusUnprocessableEntity, err.Error())
 	}
 
 	// Handle all other errors
-	_ = EncodeJSONResponse(err.Error(), &result.Code, w)
+	code := errorStatusCode(err)
+	if result != nil && result.Code != 0 {
+		code = result.Code
+	}
+	switch {
+	case errors.Is(err, domain.ErrInvalidPeriod):
+		return problemOf(problemInvalidPeriod, code, err.Error())
+	case errors.Is(err, domain.ErrInvalidParameter):
+		return problemOf(problemInvalidParameter, code, err.Error())
+	case code == http.StatusNotFound:
+		return problemOf(problemNotFound, code, "no data found for the requested parameters")
+	case code == http.StatusServiceUnavailable:
+		return problemOf(problemUpstreamUnavailable, code, "the data store is temporarily unavailable, retry later")
+	default:
+		return problemOf(problemInternal, code, "the request could not be processed")
+	}
+}
+
+// invalidParams lists the request fields named by a ParsingError, RequiredError or domain.FieldError.
+func invalidParams(err error) []ProblemInvalidParam {
+	var parsingErr *ParsingError
+	if errors.As(err, &parsingErr) && parsingErr.Param != "" {
+		return []ProblemInvalidParam{{Name: parsingErr.Param, Reason: parsingErr.Err.Error()}}
+	}
+
+	var requiredErr *RequiredError
+	if errors.As(err, &requiredErr) {
+		return []ProblemInvalidParam{{Name: requiredErr.Field, Reason: errMsgRequiredMissing}}
+	}
+
+	var fieldErr *domain.FieldError
+	if errors.As(err, &fieldErr) {
+		return []ProblemInvalidParam{{Name: fieldErr.Field, Reason: fieldErr.Reason}}
+	}
+
+	return nil
+}
+
+func problemOf(t problemType, code int, detail string) Problem {
+	return Problem{
+		Type:   t.URI,
+		Title:  t.Title,
+		Status: int32(code),
+		Detail: detail,
+	}
+}
+
+// errorStatusCode maps domain errors to the HTTP status codes promised by the API specification.
+func errorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPeriod), errors.Is(err, domain.ErrInvalidParameter):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrUpstreamUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
 }
@@ -10,6 +10,10 @@
 
 package openapi
 
+import (
+	"time"
+)
+
 type Averageregionincomes struct {
 	RegionId int32 `json:"RegionId,omitempty"`
 
@@ -19,7 +23,21 @@ type Averageregionincomes struct {
 
 	Quarter int32 `json:"Quarter,omitempty"`
 
-	AverageRegionIncomes float32 `json:"AverageRegionIncomes,omitempty"`
+	AverageRegionIncomes Decimal `json:"AverageRegionIncomes,omitempty"`
+
+	FirstYear int32 `json:"FirstYear,omitempty"`
+
+	FirstQuarter int32 `json:"FirstQuarter,omitempty"`
+
+	LastYear int32 `json:"LastYear,omitempty"`
+
+	LastQuarter int32 `json:"LastQuarter,omitempty"`
+
+	QuartersCount int32 `json:"QuartersCount,omitempty"`
+
+	Complete bool `json:"Complete"`
+
+	LoadedAt time.Time `json:"LoadedAt,omitempty"`
 }
 
 // AssertAverageregionincomesRequired checks if the required fields are not zero-ed
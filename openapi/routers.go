@@ -13,6 +13,7 @@ package openapi
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/gorilla/mux"
 	"io"
 	"mime/multipart"
@@ -98,6 +99,13 @@ func EncodeJSONResponse(i interface{}, status *int, w http.ResponseWriter) error
 	return nil
 }
 
+// EncodeProblemResponse writes an RFC 7807 problem document with its status code
+func EncodeProblemResponse(problem Problem, w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json; charset=UTF-8")
+	w.WriteHeader(int(problem.Status))
+	return json.NewEncoder(w).Encode(problem)
+}
+
 // ReadFormFileToTempFile reads file data from a request form and writes it to a temporary file
 func ReadFormFileToTempFile(r *http.Request, key string) (*os.File, error) {
 	_, fileHeader, err := r.FormFile(key)
@@ -265,7 +273,7 @@ type Constraint[T Number | string | bool] func(actual T) error
 func WithMinimum[T Number](expected T) Constraint[T] {
 	return func(actual T) error {
 		if actual < expected {
-			return errors.New(errMsgMinValueConstraint)
+			return fmt.Errorf("%s %v", errMsgMinValueConstraint, expected)
 		}
 
 		return nil
@@ -275,7 +283,7 @@ func WithMinimum[T Number](expected T) Constraint[T] {
 func WithMaximum[T Number](expected T) Constraint[T] {
 	return func(actual T) error {
 		if actual > expected {
-			return errors.New(errMsgMaxValueConstraint)
+			return fmt.Errorf("%s %v", errMsgMaxValueConstraint, expected)
 		}
 
 		return nil
@@ -2,93 +2,225 @@ package tools
 
 import (
 	"fmt"
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
 	"github.com/xuri/excelize/v2"
-	"regexp"
 	"strconv"
 	"strings"
 )
 
-func emptySheetsMap(file *excelize.File) (map[string]bool, error) {
-	sheets := file.GetSheetList()
-	emptyMap := make(map[string]bool)
-	for _, sheet := range sheets {
-		rows, err := file.GetRows(sheet)
+// FormattingFileRows returns the data rows of every non-empty sheet the layout selects, sheet by
+// sheet in workbook order and row by row within a sheet. Each row carries its sheet, its 1-based
+// row number and the period header ("YYYY.Q" for a quarter, "YYYY" for an annual total) of its
+// own sheet, as sheets may cover different periods; its cells are the region name followed by
+// the value of each period. A sheet that does not match the layout fails the whole file rather
+// than being read with shifted columns.
+//
+// Sheets are read once with the streaming row iterator of excelize and columns are mapped
+// logically, so the workbook is not modified and memory does not grow with the size of a sheet
+// beyond the rows returned.
+func FormattingFileRows(file *excelize.File, layout *domain.SheetLayout) ([]*domain.SourceRow, error) {
+	newRows := make([]*domain.SourceRow, 0)
+
+	selectedSheets := 0
+	for _, sheet := range file.GetSheetList() {
+		if !layout.SelectsSheet(sheet) {
+			continue
+		}
+		selectedSheets++
+
+		sheetRows, err := sheetDataRows(file, sheet, layout)
 		if err != nil {
-			return nil, fmt.Errorf("error getting rows in empty sheets checking: %v", err)
+			return nil, fmt.Errorf("sheet [%s] does not match the layout: %w", sheet, err)
 		}
-		emptyMap[sheet] = len(rows) == 0
+		newRows = append(newRows, sheetRows...)
 	}
-	return emptyMap, nil
+	if selectedSheets == 0 {
+		return nil, fmt.Errorf("no sheet of %v is selected by include %v and exclude %v", file.GetSheetList(), layout.IncludeSheets, layout.ExcludeSheets)
+	}
+	return newRows, nil
 }
 
-func FormattingFileRows(file *excelize.File) ([][]string, error) {
-	emptySheetsMap, err := emptySheetsMap(file)
+// sheetColumns is the logical view of a sheet's columns: where the region names are and which
+// column holds the value of each period of header.
+type sheetColumns struct {
+	region int
+	header []string
+	values []int
+}
+
+// mapSheetColumns reads the period headers. A period covers the columns from its header cell up to
+// the next non-empty header cell. Each non-empty sub-header of a period must name either the
+// annual total, at most once, or a quarter, which is taken from the sub-header rather than from
+// the column position, so a period starting later in the year keeps its quarter numbers.
+func mapSheetColumns(headerRow []string, subHeaderRow []string, layout *domain.SheetLayout) (*sheetColumns, error) {
+	regionColumn, err := excelize.ColumnNameToNumber(layout.RegionColumn)
 	if err != nil {
-		return nil, fmt.Errorf("error getting emptySheetsMap: %v", err)
+		return nil, fmt.Errorf("invalid region column [%s]: %w", layout.RegionColumn, err)
 	}
-
-	newRows := make([][]string, 0)
-
-	for sheet, isEmpty := range emptySheetsMap {
-		if !isEmpty {
-			for i := 0; i < 2; i++ {
-				err := file.RemoveRow(sheet, 1)
-				if err != nil {
-					fmt.Printf("error removing row: %v\n", err)
-				}
-			}
-
-			err = file.RemoveCol(sheet, "B")
-			if err != nil {
-				fmt.Printf("error removing col: %v\n", err)
+	columns := &sheetColumns{region: regionColumn - 1, header: []string{""}}
+
+	year := ""
+	quarters := make(map[int]bool)
+	hasAnnual := false
+	for idx := 0; idx < max(len(headerRow), len(subHeaderRow)); idx++ {
+		if headerCell := strings.TrimSpace(cellValue(headerRow, idx)); headerCell != "" {
+			year, quarters, hasAnnual = "", make(map[int]bool), false
+			if match := layout.PeriodPattern.FindStringSubmatch(headerCell); match != nil {
+				year = match[1]
 			}
+		}
+		if year == "" {
+			continue
+		}
+		if idx == columns.region {
+			return nil, fmt.Errorf("region column %s is inside period [%s]", layout.RegionColumn, year)
+		}
 
-			var colsToDelete []int
-
-			rows, err := file.GetRows(sheet)
-			if err != nil {
-				fmt.Printf("Error getting rows: %v\n", err)
+		subHeaderCell := strings.TrimSpace(cellValue(subHeaderRow, idx))
+		if subHeaderCell == "" {
+			continue
+		}
+		if layout.AnnualPattern.MatchString(subHeaderCell) {
+			if hasAnnual {
+				return nil, fmt.Errorf("period [%s] has more than one annual column in row %d", year, layout.SubHeaderRow)
 			}
+			hasAnnual = true
+			columns.header = append(columns.header, year)
+			columns.values = append(columns.values, idx)
+			continue
+		}
+		quarter, ok := layout.QuarterNumber(subHeaderCell)
+		if !ok {
+			return nil, fmt.Errorf("period [%s] has sub-header [%s] in row %d that is neither a quarter matching [%s] nor an annual total matching [%s]",
+				year, subHeaderCell, layout.SubHeaderRow, layout.QuarterPattern, layout.AnnualPattern)
+		}
+		if quarters[quarter] {
+			return nil, fmt.Errorf("period [%s] has more than one column of quarter %d in row %d", year, quarter, layout.SubHeaderRow)
+		}
+		quarters[quarter] = true
+		columns.header = append(columns.header, year+"."+strconv.Itoa(quarter))
+		columns.values = append(columns.values, idx)
+	}
 
-			for idx, row := range rows[1] {
-				if strings.Contains(row, "год") {
-					colsToDelete = append(colsToDelete, idx+1)
-				}
-			}
+	if len(columns.values) == 0 {
+		return nil, fmt.Errorf("no period headers matching [%s] in row %d", layout.PeriodPattern, layout.HeaderRow)
+	}
+	return columns, nil
+}
 
-			for i := len(colsToDelete) - 1; i >= 0; i-- {
-				colName, _ := excelize.ColumnNumberToName(colsToDelete[i])
-				if err := file.RemoveCol(sheet, colName); err != nil {
-					fmt.Printf("error removing col: %v\n", err)
-				}
-			}
+// sheetDataRows streams the rows of a sheet. The last FooterRows non-empty rows are not known to
+// be the footer until the sheet ends, so only that many rows are held back before being returned.
+// Blank rows between regions are skipped. An empty sheet has no data rows and is not an error.
+func sheetDataRows(file *excelize.File, sheet string, layout *domain.SheetLayout) ([]*domain.SourceRow, error) {
+	rows, err := file.Rows(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+	defer rows.Close()
+
+	var headerRow []string
+	var columns *sheetColumns
+	sheetRows := make([]*domain.SourceRow, 0)
+	valueCount := 0
+	lastRow := 0
+
+	// pending holds the rows that may still turn out to be the footer, trailingEmpty counts the
+	// empty rows at its end, which do not count towards the footer.
+	pending := make([]*domain.SourceRow, 0, layout.FooterRows+1)
+	trailingEmpty := 0
+
+	rowNumber := 0
+readRows:
+	for rows.Next() {
+		rowNumber++
+		// Data cells are read as stored rather than through their number format, which excelize
+		// renders in its own conventions; headers keep their formatted text.
+		var options []excelize.Options
+		if rowNumber >= layout.FirstDataRow {
+			options = append(options, excelize.Options{RawCellValue: true})
+		}
+		row, err := rows.Columns(options...)
+		if err != nil {
+			return nil, fmt.Errorf("error reading row %d: %w", rowNumber, err)
+		}
+		if len(row) > 0 {
+			lastRow = rowNumber
+		}
 
-			rows, err = file.GetRows(sheet)
+		switch {
+		case rowNumber == layout.HeaderRow:
+			headerRow = row
+		case rowNumber == layout.SubHeaderRow:
+			columns, err = mapSheetColumns(headerRow, row, layout)
 			if err != nil {
-				fmt.Printf("error getting rows: %v\n", err)
+				return nil, err
+			}
+		case rowNumber >= layout.FirstDataRow:
+			pending = append(pending, &domain.SourceRow{Sheet: sheet, RowNumber: rowNumber, Cells: row})
+			if len(row) == 0 {
+				trailingEmpty++
+			} else {
+				trailingEmpty = 0
 			}
 
-			re := regexp.MustCompile(`(\d{4})\s+год`)
-
-			newHeaderValues := make([]string, 0)
-			newHeaderValues = append(newHeaderValues, "")
+			for len(pending)-trailingEmpty > layout.FooterRows {
+				dataRow := pending[0]
+				pending = pending[1:]
 
-			for i := 1; i < len(rows[0]); {
-				cellValue := rows[0][i]
-				match := re.FindStringSubmatch(cellValue)
+				region := cellValue(dataRow.Cells, columns.region)
+				if dataRow.RowNumber == layout.FirstDataRow && region == "" {
+					return nil, fmt.Errorf("no region name in column %s of the first data row %d", layout.RegionColumn, layout.FirstDataRow)
+				}
+				if layout.FooterPattern != nil && layout.FooterPattern.MatchString(region) {
+					break readRows
+				}
+				// A blank row between regions is a spacer, not a row without a region.
+				if len(dataRow.Cells) == 0 {
+					continue
+				}
 
-				if len(match) > 0 {
-					for q := 1; q <= 4; q++ {
-						newHeaderValues = append(newHeaderValues, match[1]+"."+strconv.Itoa(q))
-						i++
+				// Trailing empty cells are not returned by excelize, so a row shorter than the
+				// header keeps fewer values; the parser reads the absent ones as empty cells.
+				cells := []string{region}
+				for _, column := range columns.values {
+					if column >= len(dataRow.Cells) {
+						break
 					}
+					cells = append(cells, dataRow.Cells[column])
 				}
+				valueCount = max(valueCount, len(cells)-1)
+
+				dataRow.Cells = cells
+				sheetRows = append(sheetRows, dataRow)
 			}
+		}
+	}
+	if err := rows.Error(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+
+	if lastRow == 0 {
+		return nil, nil
+	}
+	if columns == nil {
+		return nil, fmt.Errorf("sheet has %d rows, the sub-header is expected in row %d", lastRow, layout.SubHeaderRow)
+	}
+	if len(sheetRows) == 0 {
+		return nil, fmt.Errorf("no data rows between row %d and the %d footer rows", layout.FirstDataRow, layout.FooterRows)
+	}
 
-			newRows = append(newRows, newHeaderValues[0:len(rows[2 : len(rows)-4][0])])
-			newRows = append(newRows, rows[2:len(rows)-4]...)
+	// The sub-header may already name quarters that are not published yet; the header ends at
+	// the last column that has a value in any row.
+	header := columns.header[:1+valueCount]
+	for _, row := range sheetRows {
+		row.Header = header
+	}
+	return sheetRows, nil
+}
 
-		}
+func cellValue(row []string, idx int) string {
+	if idx < 0 || idx >= len(row) {
+		return ""
 	}
-	return newRows, nil
+	return row[idx]
 }
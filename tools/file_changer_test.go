@@ -0,0 +1,200 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/donskova1ex/AverageRegionIncomes/internal/domain"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+type testSheet struct {
+	name string
+	rows [][]any
+}
+
+func newTestWorkbook(t *testing.T, sheets ...testSheet) *excelize.File {
+	t.Helper()
+	file := excelize.NewFile()
+	t.Cleanup(func() { _ = file.Close() })
+	for _, sheet := range sheets {
+		_, err := file.NewSheet(sheet.name)
+		require.NoError(t, err)
+		for idx, row := range sheet.rows {
+			cell, err := excelize.CoordinatesToCellName(1, idx+1)
+			require.NoError(t, err)
+			require.NoError(t, file.SetSheetRow(sheet.name, cell, &row))
+		}
+	}
+	require.NoError(t, file.DeleteSheet("Sheet1"))
+	return file
+}
+
+func incomesSheet() [][]any {
+	return [][]any{
+		{"Среднедушевые денежные доходы населения"},
+		{"рублей в месяц"},
+		{"", "Код", "2024 год", "", "", "", "", "2025 год"},
+		{"", "", "I квартал", "II квартал", "III квартал", "IV квартал", "год", "I квартал", "II квартал", "III квартал"},
+		{"Российская Федерация", "643", "46000", "50000", "52000", "60000", "52000", "49000", "54000"},
+		{"Республика Башкортостан", "80", "38000", "41000", "42000", "50000", "42750", "40000"},
+		{"1) Без учёта статистической информации по отдельным регионам"},
+		{"2) Данные предварительные"},
+		{""},
+		{"Источник: Росстат"},
+	}
+}
+
+func TestFormattingFileRowsFollowsLayout(t *testing.T) {
+	layout := domain.DefaultSheetLayout()
+	sheet := append(incomesSheet(), []any{}, []any{})
+	file := newTestWorkbook(t, testSheet{"2024-2025", sheet}, testSheet{"Пустой лист", nil})
+	original, err := file.GetRows("2024-2025")
+	require.NoError(t, err)
+
+	rows, err := FormattingFileRows(file, &layout)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	unchanged, err := file.GetRows("2024-2025")
+	require.NoError(t, err)
+	require.Equal(t, original, unchanged)
+
+	header := []string{"", "2024.1", "2024.2", "2024.3", "2024.4", "2024", "2025.1", "2025.2"}
+	require.Equal(t, &domain.SourceRow{
+		Sheet:     "2024-2025",
+		RowNumber: 5,
+		Header:    header,
+		Cells:     []string{"Российская Федерация", "46000", "50000", "52000", "60000", "52000", "49000", "54000"},
+	}, rows[0])
+	require.Equal(t, 6, rows[1].RowNumber)
+	require.Equal(t, header, rows[1].Header)
+	require.Equal(t, []string{"Республика Башкортостан", "38000", "41000", "42000", "50000", "42750", "40000"}, rows[1].Cells)
+}
+
+func TestFormattingFileRowsSkipsBlankRowsBetweenRegions(t *testing.T) {
+	layout := domain.DefaultSheetLayout()
+	sheet := incomesSheet()
+	sheet = append(sheet[:5:5], append([][]any{{}}, sheet[5:]...)...)
+	file := newTestWorkbook(t, testSheet{"2024-2025", sheet})
+
+	rows, err := FormattingFileRows(file, &layout)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, 5, rows[0].RowNumber)
+	require.Equal(t, "Российская Федерация", rows[0].Cells[0])
+	require.Equal(t, 7, rows[1].RowNumber)
+	require.Equal(t, "Республика Башкортостан", rows[1].Cells[0])
+}
+
+func TestFormattingFileRowsReadsRawNumericValues(t *testing.T) {
+	layout := domain.DefaultSheetLayout()
+	sheet := incomesSheet()
+	sheet[5] = []any{"Республика Башкортостан", "80", 38000.5, 41000, 42000, 50000, 42750.125, "40 000,5"}
+	file := newTestWorkbook(t, testSheet{"2024-2025", sheet})
+	style, err := file.NewStyle(&excelize.Style{NumFmt: 4})
+	require.NoError(t, err)
+	require.NoError(t, file.SetCellStyle("2024-2025", "C6", "H6", style))
+
+	formatted, err := file.GetCellValue("2024-2025", "C6")
+	require.NoError(t, err)
+	require.Equal(t, "38,000.50", formatted)
+
+	rows, err := FormattingFileRows(file, &layout)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Республика Башкортостан", "38000.5", "41000", "42000", "50000", "42750.125", "40 000,5"}, rows[1].Cells)
+}
+
+func TestFormattingFileRowsRejectsMismatchedLayout(t *testing.T) {
+	shiftedHeader := incomesSheet()
+	shiftedHeader = append([][]any{{"Новая строка заголовка"}}, shiftedHeader...)
+	unknownQuarter := incomesSheet()
+	unknownQuarter[3][6] = "V квартал"
+	twoAnnualColumns := incomesSheet()
+	twoAnnualColumns[3][5] = "год"
+	cumulativeColumn := incomesSheet()
+	cumulativeColumn[3][3] = "январь-июнь"
+	repeatedQuarter := incomesSheet()
+	repeatedQuarter[3][4] = "II квартал"
+
+	testCases := []struct {
+		name  string
+		rows  [][]any
+		error string
+	}{
+		{name: "header moved down", rows: shiftedHeader, error: "no period headers"},
+		{name: "unknown quarter sub-header", rows: unknownQuarter, error: "sub-header [V квартал] in row 4 that is neither a quarter"},
+		{name: "two annual columns", rows: twoAnnualColumns, error: "more than one annual column"},
+		{name: "cumulative column", rows: cumulativeColumn, error: "sub-header [январь-июнь] in row 4 that is neither a quarter"},
+		{name: "quarter repeated", rows: repeatedQuarter, error: "more than one column of quarter 2"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			layout := domain.DefaultSheetLayout()
+			file := newTestWorkbook(t, testSheet{"Sheet", testCase.rows})
+
+			_, err := FormattingFileRows(file, &layout)
+			require.ErrorContains(t, err, testCase.error)
+		})
+	}
+}
+
+func TestFormattingFileRowsTakesQuartersFromSubHeaders(t *testing.T) {
+	sheet := [][]any{
+		{"Среднедушевые денежные доходы населения"},
+		{"рублей в месяц"},
+		{"", "Код", "2019 год", "", "", "2020 год"},
+		{"", "", "II квартал", "3 квартал", "IV квартал", "I квартал"},
+		{"Российская Федерация", "643", "35000", "36000", "42000", "35500"},
+		{"1) Без учёта статистической информации по отдельным регионам"},
+		{"2) Данные предварительные"},
+		{""},
+		{"Источник: Росстат"},
+	}
+	layout := domain.DefaultSheetLayout()
+	file := newTestWorkbook(t, testSheet{"2019-2020", sheet})
+
+	rows, err := FormattingFileRows(file, &layout)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, []string{"", "2019.2", "2019.3", "2019.4", "2020.1"}, rows[0].Header)
+	require.Equal(t, []string{"Российская Федерация", "35000", "36000", "42000", "35500"}, rows[0].Cells)
+}
+
+func TestFormattingFileRowsReadsSelectedSheetsInOrder(t *testing.T) {
+	older := [][]any{
+		{"Среднедушевые денежные доходы населения"},
+		{"рублей в месяц"},
+		{"", "Код", "2018 год"},
+		{"", "", "I квартал", "II квартал", "III квартал", "IV квартал", "год"},
+		{"Российская Федерация", "643", "30000", "32000", "33000", "40000", "33750"},
+		{"1) Без учёта статистической информации по отдельным регионам"},
+		{"2) Данные предварительные"},
+		{""},
+		{"Источник: Росстат"},
+	}
+	notes := [][]any{{"Методологические пояснения"}}
+
+	layout := domain.DefaultSheetLayout()
+	layout.IncludeSheets = []string{"20*"}
+	layout.ExcludeSheets = []string{"2015-2017"}
+	file := newTestWorkbook(t,
+		testSheet{"2024-2025", incomesSheet()},
+		testSheet{"Пояснения", notes},
+		testSheet{"2015-2017", notes},
+		testSheet{"2018", older},
+	)
+
+	for attempt := 0; attempt < 5; attempt++ {
+		rows, err := FormattingFileRows(file, &layout)
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		require.Equal(t, []string{"2024-2025", "2024-2025", "2018"}, []string{rows[0].Sheet, rows[1].Sheet, rows[2].Sheet})
+		require.Equal(t, []string{"", "2018.1", "2018.2", "2018.3", "2018.4", "2018"}, rows[2].Header)
+		require.Equal(t, "2025.2", rows[0].Header[len(rows[0].Header)-1])
+	}
+
+	layout.IncludeSheets = []string{"2030"}
+	_, err := FormattingFileRows(file, &layout)
+	require.ErrorContains(t, err, "no sheet")
+}